@@ -0,0 +1,236 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the API while the circuit
+// breaker is open after a run of server errors.
+var ErrCircuitOpen = errors.New("circuit breaker is open - bunny stream api is failing")
+
+// DefaultCircuitCoolDown is how long an open circuit waits before letting a
+// probe request through.
+const DefaultCircuitCoolDown = 30 * time.Second
+
+// CircuitState is the state of the circuit breaker.
+type CircuitState int
+
+// Circuit breaker states.
+const (
+	// CircuitClosed lets every request through. This is the normal state.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen fails every request with ErrCircuitOpen until the
+	// cool-down has elapsed.
+	CircuitOpen
+
+	// CircuitHalfOpen lets a limited number of probe requests through. A
+	// successful probe closes the circuit, a failed one opens it again.
+	CircuitHalfOpen
+)
+
+// String returns the name of the state, e.g. "open".
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "CircuitState(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// CircuitBreaker configures the client's circuit breaker. While the API
+// keeps failing with server errors or transport failures, it stops sending
+// requests and returns ErrCircuitOpen immediately, so that callers fail fast
+// instead of piling up on retries.
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failed requests that
+	// opens the circuit. Zero or less disables the breaker.
+	FailureThreshold int
+
+	// CoolDown is how long the circuit stays open before probing the API
+	// again. Defaults to DefaultCircuitCoolDown.
+	CoolDown time.Duration
+
+	// HalfOpenRequests is the number of probe requests let through at once
+	// while half-open. Defaults to 1.
+	HalfOpenRequests int
+
+	// OnStateChange, if set, is called after every state transition.
+	OnStateChange func(from, to CircuitState)
+}
+
+// attemptOutcome is what a request attempt tells the breaker about the API.
+type attemptOutcome int
+
+const (
+	outcomeSuccess attemptOutcome = iota // the API answered
+	outcomeFailure                       // server error or transport failure
+	outcomeIgnored                       // says nothing about the API, e.g. canceled
+)
+
+// breaker implements the circuit breaker shared by every goroutine using a
+// Client.
+type breaker struct {
+	cfg CircuitBreaker
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// newBreaker returns a breaker for cfg, or nil if cfg disables it.
+func newBreaker(cfg CircuitBreaker) *breaker {
+	if cfg.FailureThreshold < 1 {
+		return nil
+	}
+
+	if cfg.CoolDown <= 0 {
+		cfg.CoolDown = DefaultCircuitCoolDown
+	}
+	if cfg.HalfOpenRequests < 1 {
+		cfg.HalfOpenRequests = 1
+	}
+
+	return &breaker{cfg: cfg}
+}
+
+// allow reports whether a request may be sent, returning ErrCircuitOpen if
+// not.
+func (b *breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	from := b.state
+
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.cfg.CoolDown {
+		b.state = CircuitHalfOpen
+		b.probes = 0
+	}
+
+	var err error
+	switch b.state {
+	case CircuitOpen:
+		err = ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probes < b.cfg.HalfOpenRequests {
+			b.probes++
+		} else {
+			err = ErrCircuitOpen
+		}
+	}
+
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+	return err
+}
+
+// record updates the breaker with the outcome of an allowed request.
+func (b *breaker) record(outcome attemptOutcome) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	from := b.state
+
+	switch b.state {
+	case CircuitClosed:
+		switch outcome {
+		case outcomeSuccess:
+			b.failures = 0
+		case outcomeFailure:
+			b.failures++
+			if b.failures >= b.cfg.FailureThreshold {
+				b.open()
+			}
+		}
+	case CircuitHalfOpen:
+		switch outcome {
+		case outcomeSuccess:
+			b.state = CircuitClosed
+			b.failures = 0
+		case outcomeFailure:
+			b.open()
+		default:
+			b.probes = max(b.probes-1, 0)
+		}
+	}
+
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+// open trips the circuit. Callers hold b.mu.
+func (b *breaker) open() {
+	b.state = CircuitOpen
+	b.openedAt = time.Now()
+	b.failures = 0
+}
+
+// notify calls OnStateChange if the state changed. It runs without the lock
+// so the callback may use the client.
+func (b *breaker) notify(from, to CircuitState) {
+	if from != to && b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, to)
+	}
+}
+
+// currentState returns the state of the breaker.
+func (b *breaker) currentState() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// classifyAttempt tells whether an attempt shows the API failing. Server
+// errors and transport failures count; client errors and rate limits show
+// a responsive API; canceled requests are ignored.
+func classifyAttempt(ctx context.Context, resp *Response, err error) attemptOutcome {
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case ctx.Err() != nil || errors.Is(err, context.Canceled):
+		return outcomeIgnored
+	case resp != nil:
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return outcomeFailure
+		}
+		return outcomeSuccess
+	}
+
+	// Certificate and scheme errors are the caller's setup, not an outage.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && !isPermanentTransportError(err) {
+		return outcomeFailure
+	}
+	return outcomeIgnored
+}
+
+// CircuitState returns the current state of the client's circuit breaker.
+// It is always CircuitClosed when Config.CircuitBreaker is not set.
+func (c *Client) CircuitState() CircuitState {
+	return c.breaker.currentState()
+}
@@ -0,0 +1,191 @@
+package bunnystream
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// breakerServer returns a client with a circuit breaker, talking to a
+// server answering with the status stored in status.
+func breakerServer(t *testing.T, cfg CircuitBreaker) (*Client, *atomic.Int32, *atomic.Int32, func()) {
+	t.Helper()
+
+	var status, hits atomic.Int32
+	status.Store(http.StatusOK)
+
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(int(status.Load()))
+		w.Write([]byte(`{}`))
+	})
+	c.config.MaxRetries = 0
+	c.breaker = newBreaker(cfg)
+
+	return c, &status, &hits, srv.Close
+}
+
+// -----------------------------------------------------------------------------
+// CircuitState
+// -----------------------------------------------------------------------------
+
+func TestCircuitState_String(t *testing.T) {
+	tests := map[CircuitState]string{
+		CircuitClosed:   "closed",
+		CircuitOpen:     "open",
+		CircuitHalfOpen: "half-open",
+		CircuitState(9): "CircuitState(9)",
+	}
+	for state, want := range tests {
+		if got := state.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Breaker
+// -----------------------------------------------------------------------------
+
+func TestBreaker_DisabledByDefault(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+	if c.breaker != nil {
+		t.Error("breaker should be nil without Config.CircuitBreaker")
+	}
+	if c.CircuitState() != CircuitClosed {
+		t.Errorf("CircuitState = %v, want closed", c.CircuitState())
+	}
+}
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	c, status, hits, done := breakerServer(t, CircuitBreaker{FailureThreshold: 3, CoolDown: time.Hour})
+	defer done()
+	status.Store(http.StatusServiceUnavailable)
+
+	for range 3 {
+		if _, _, err := c.GetVideo(context.Background(), "video-abc"); !errors.Is(err, ErrServiceUnavailable) {
+			t.Fatalf("expected ErrServiceUnavailable, got %v", err)
+		}
+	}
+	if c.CircuitState() != CircuitOpen {
+		t.Fatalf("CircuitState = %v, want open", c.CircuitState())
+	}
+
+	_, _, err := c.GetVideo(context.Background(), "video-abc")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("server hits = %d, want 3; an open circuit must not call the API", hits.Load())
+	}
+}
+
+func TestBreaker_SuccessResetsFailureCount(t *testing.T) {
+	c, status, _, done := breakerServer(t, CircuitBreaker{FailureThreshold: 2})
+	defer done()
+
+	for _, code := range []int{500, 200, 500, 404, 500} {
+		status.Store(int32(code))
+		c.GetVideo(context.Background(), "video-abc")
+	}
+
+	if c.CircuitState() != CircuitClosed {
+		t.Errorf("CircuitState = %v, want closed; failures were not consecutive", c.CircuitState())
+	}
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	var transitions []string
+	c, status, _, done := breakerServer(t, CircuitBreaker{
+		FailureThreshold: 1,
+		CoolDown:         5 * time.Millisecond,
+		OnStateChange: func(from, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	defer done()
+
+	status.Store(http.StatusInternalServerError)
+	c.GetVideo(context.Background(), "video-abc") // opens
+
+	time.Sleep(10 * time.Millisecond)
+	c.GetVideo(context.Background(), "video-abc") // failed probe, opens again
+
+	if c.CircuitState() != CircuitOpen {
+		t.Fatalf("CircuitState = %v, want open after a failed probe", c.CircuitState())
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	status.Store(http.StatusOK)
+	if _, _, err := c.GetVideo(context.Background(), "video-abc"); err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+
+	if c.CircuitState() != CircuitClosed {
+		t.Errorf("CircuitState = %v, want closed after a successful probe", c.CircuitState())
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transitions = %v, want %v", transitions, want)
+			break
+		}
+	}
+}
+
+func TestBreaker_HalfOpenLimitsProbes(t *testing.T) {
+	b := newBreaker(CircuitBreaker{FailureThreshold: 1, CoolDown: time.Millisecond, HalfOpenRequests: 2})
+	b.record(outcomeFailure)
+	time.Sleep(2 * time.Millisecond)
+
+	for i := range 2 {
+		if err := b.allow(); err != nil {
+			t.Fatalf("probe %d: %v", i, err)
+		}
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("third probe = %v, want ErrCircuitOpen", err)
+	}
+
+	// A canceled probe frees its slot.
+	b.record(outcomeIgnored)
+	if err := b.allow(); err != nil {
+		t.Errorf("probe after a canceled one = %v, want nil", err)
+	}
+}
+
+func TestClassifyAttempt(t *testing.T) {
+	ctx := context.Background()
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		resp *Response
+		err  error
+		want attemptOutcome
+	}{
+		{"success", ctx, &Response{StatusCode: 200}, nil, outcomeSuccess},
+		{"server error", ctx, &Response{StatusCode: 502}, apiErr(502), outcomeFailure},
+		{"client error", ctx, &Response{StatusCode: 404}, apiErr(404), outcomeSuccess},
+		{"rate limited", ctx, &Response{StatusCode: 429}, apiErr(429), outcomeSuccess},
+		{"transport", ctx, nil, transportErr(errors.New("connection reset")), outcomeFailure},
+		{"certificate", ctx, nil, transportErr(x509.UnknownAuthorityError{}), outcomeIgnored},
+		{"canceled", canceled, nil, transportErr(context.Canceled), outcomeIgnored},
+		{"other", ctx, nil, errors.New("boom"), outcomeIgnored},
+	}
+	for _, tt := range tests {
+		if got := classifyAttempt(tt.ctx, tt.resp, tt.err); got != tt.want {
+			t.Errorf("%s: classifyAttempt = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
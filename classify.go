@@ -0,0 +1,83 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+)
+
+// IsTemporary reports whether err is caused by a condition expected to clear
+// by itself: rate limiting (429), request timeouts (408), server errors the
+// client retries (500, 502, 503, 504), network timeouts, including an
+// exceeded context deadline, and an open circuit breaker.
+func IsTemporary(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ErrRateLimited) ||
+		errors.Is(err, ErrInternalServer) ||
+		errors.Is(err, ErrServiceUnavailable) ||
+		errors.Is(err, ErrCircuitOpen) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.StatusCode)
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsRetryable reports whether sending the same call again may succeed. It
+// covers everything IsTemporary does, plus transport failures such as
+// refused or reset connections and uploads cut short with
+// io.ErrUnexpectedEOF. Calls canceled through their context and certificate
+// errors are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if IsTemporary(err) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	// Transport failures the client itself would retry; an API response
+	// was never received.
+	var apiErr *APIError
+	return !errors.As(err, &apiErr) && isTransient(err)
+}
+
+// IsClientError reports whether err is an API error caused by the request
+// itself (4xx), which fails the same way when retried. Rate limiting (429)
+// and request timeouts (408) are not client errors.
+func IsClientError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return apiErr.StatusCode >= 400 && apiErr.StatusCode < 500
+}
+
+// IsAuthError reports whether err is caused by an invalid API key (401) or
+// insufficient permissions or token authentication (403).
+func IsAuthError(err error) bool {
+	return errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrForbidden)
+}
+
+// IsNotFound reports whether err reports a missing resource, whichever kind
+// of resource it is.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
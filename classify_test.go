@@ -0,0 +1,117 @@
+package bunnystream
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+)
+
+// apiErr returns the error checkResponseError builds for status.
+func apiErr(status int) error {
+	req, _ := http.NewRequest(http.MethodGet, "https://video.bunnycdn.com/library/123/videos/abc", nil)
+	return (&Client{}).checkResponseError(req, &Response{StatusCode: status, Headers: http.Header{}})
+}
+
+// timeoutErr is a net.Error reporting a timeout.
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+func transportErr(err error) error {
+	return fmt.Errorf("failed to perform request: %w", &url.Error{Op: "Get", URL: "https://video.bunnycdn.com", Err: err})
+}
+
+// -----------------------------------------------------------------------------
+// Classification helpers
+// -----------------------------------------------------------------------------
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		name                                   string
+		err                                    error
+		temporary, retryable, client, auth, nf bool
+	}{
+		{name: "nil"},
+		{name: "400", err: apiErr(http.StatusBadRequest), client: true},
+		{name: "401", err: apiErr(http.StatusUnauthorized), client: true, auth: true},
+		{name: "403", err: apiErr(http.StatusForbidden), client: true, auth: true},
+		{name: "404", err: apiErr(http.StatusNotFound), client: true, nf: true},
+		{name: "408", err: apiErr(http.StatusRequestTimeout), temporary: true, retryable: true},
+		{name: "429", err: apiErr(http.StatusTooManyRequests), temporary: true, retryable: true},
+		{name: "500", err: apiErr(http.StatusInternalServerError), temporary: true, retryable: true},
+		{name: "502", err: apiErr(http.StatusBadGateway), temporary: true, retryable: true},
+		{name: "504", err: apiErr(http.StatusGatewayTimeout), temporary: true, retryable: true},
+		{name: "501", err: apiErr(http.StatusNotImplemented)},
+		{name: "bare sentinel", err: ErrServiceUnavailable, temporary: true, retryable: true},
+		{name: "circuit open", err: ErrCircuitOpen, temporary: true, retryable: true},
+		{name: "collection not found", err: ErrCollectionNotFound, nf: true},
+		{name: "validation", err: ErrTitleRequired},
+		{name: "net timeout", err: transportErr(timeoutErr{}), temporary: true, retryable: true},
+		{name: "connection refused", err: transportErr(syscall.ECONNREFUSED), retryable: true},
+		{name: "certificate", err: transportErr(x509.UnknownAuthorityError{})},
+		{name: "unexpected eof", err: fmt.Errorf("upload: %w", io.ErrUnexpectedEOF), retryable: true},
+		{name: "deadline", err: context.DeadlineExceeded, temporary: true, retryable: true},
+		{name: "canceled", err: transportErr(context.Canceled)},
+		{name: "other", err: errors.New("boom")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTemporary(tt.err); got != tt.temporary {
+				t.Errorf("IsTemporary = %v, want %v", got, tt.temporary)
+			}
+			if got := IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf("IsRetryable = %v, want %v", got, tt.retryable)
+			}
+			if got := IsClientError(tt.err); got != tt.client {
+				t.Errorf("IsClientError = %v, want %v", got, tt.client)
+			}
+			if got := IsAuthError(tt.err); got != tt.auth {
+				t.Errorf("IsAuthError = %v, want %v", got, tt.auth)
+			}
+			if got := IsNotFound(tt.err); got != tt.nf {
+				t.Errorf("IsNotFound = %v, want %v", got, tt.nf)
+			}
+		})
+	}
+}
+
+func TestIsRetryable_MatchesDoRequest(t *testing.T) {
+	for status := 400; status < 600; status++ {
+		err := apiErr(status)
+		if IsRetryable(err) != isTransient(err) {
+			t.Errorf("status %d: IsRetryable = %v, doRequest retries = %v", status, IsRetryable(err), isTransient(err))
+		}
+	}
+}
+
+func TestIsRetryable_RealTransportFailure(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cfg := baseConfig()
+	cfg.BaseURL = "http://" + addr
+	c := mustNewClient(t, cfg)
+	c.config.MaxRetries = 0
+
+	_, _, err = c.GetVideo(context.Background(), "video-abc")
+	if err == nil {
+		t.Fatal("expected a transport error")
+	}
+	if !IsRetryable(err) {
+		t.Errorf("IsRetryable(%v) = false, want true", err)
+	}
+}
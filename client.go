@@ -7,15 +7,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 // Client is the Bunny Stream API client.
 type Client struct {
-	config     *Config
-	httpClient *http.Client
-	baseURL    string
-	libraryID  string
-	apiKey     string
+	config        *Config
+	doer          Doer
+	limiter       *limiter
+	uploadLimiter *limiter
+	breaker       *breaker
+	baseURL       string
+	libraryID     string
+	apiKey        string
 }
 
 // NewClient creates a new Bunny Stream client.
@@ -32,11 +36,14 @@ func NewClient(cfg *Config) (*Client, error) {
 	cfg.init()
 
 	return &Client{
-		config:     cfg,
-		httpClient: cfg.HTTPClient,
-		baseURL:    cfg.BaseURL,
-		libraryID:  cfg.LibraryID,
-		apiKey:     cfg.APIKey,
+		config:        cfg,
+		doer:          chainMiddleware(cfg.HTTPClient, cfg.Middleware),
+		limiter:       newLimiter(cfg.RateLimit, cfg.RetryWaitMax),
+		uploadLimiter: newLimiter(cfg.UploadRateLimit, cfg.RetryWaitMax),
+		breaker:       newBreaker(cfg.CircuitBreaker),
+		baseURL:       cfg.BaseURL,
+		libraryID:     cfg.LibraryID,
+		apiKey:        cfg.APIKey,
 	}, nil
 }
 
@@ -47,6 +54,9 @@ func (c *Client) request(ctx context.Context, method, url string, body io.Reader
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	// Make seekable bodies replayable so retries resend the full payload.
+	setReplayableBody(req, body)
+
 	// Set headers
 	req.Header.Set("AccessKey", c.apiKey)
 	req.Header.Set("User-Agent", c.config.UserAgent)
@@ -58,9 +68,78 @@ func (c *Client) request(ctx context.Context, method, url string, body io.Reader
 }
 
 // doRequest performs an HTTP request and returns the response.
+// Rate limits, server errors and transport failures are retried up to
+// Config.MaxRetries times with jittered exponential backoff; POST requests
+// only when they were refused or never sent (see shouldRetry). A Retry-After
+// longer than Config.RetryWaitMax ends the retries early. Every attempt
+// waits for the configured rate limiter first, and fails with ErrCircuitOpen
+// while the circuit breaker is open.
 func (c *Client) doRequest(req *http.Request) (*Response, error) {
+	limiter := c.limiterFor(req)
+
+	for attempt := 0; ; attempt++ {
+		if err := limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+
+		response, err := c.sendObserved(req, attempt)
+		limiter.observe(response)
+		c.breaker.record(classifyAttempt(req.Context(), response, err))
+		if err == nil || attempt >= c.config.MaxRetries || !shouldRetry(req, err) {
+			return response, err
+		}
+
+		delay, ok := c.retryDelay(attempt, response)
+		if !ok {
+			return response, err
+		}
+
+		c.config.Metrics.ObserveRetry(c.endpoint(req.URL), req.Method)
+
+		if waitErr := sleepContext(req.Context(), delay); waitErr != nil {
+			return response, fmt.Errorf("%w: %w", waitErr, err)
+		}
+
+		if req, err = rewindRequest(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sendObserved sends req like send, and records the attempt in
+// Config.Metrics and, when set, Config.Logger.
+func (c *Client) sendObserved(req *http.Request, attempt int) (*Response, error) {
+	sent := countBody(req)
+	start := time.Now()
+	response, err := c.send(req)
+	elapsed := time.Since(start)
+
+	status := 0
+	if response != nil {
+		status = response.StatusCode
+	}
+
+	endpoint := c.endpoint(req.URL)
+	c.config.Metrics.ObserveRequest(endpoint, req.Method, status, elapsed)
+	if n := sent.n.Load(); n > 0 && isUploadRequest(req) {
+		c.config.Metrics.ObserveUploadBytes(endpoint, n)
+	}
+
+	if c.config.Logger != nil {
+		c.logAttempt(req.Context(), req, attempt, sent.n.Load(), response, err, elapsed)
+	}
+
+	return response, err
+}
+
+// send performs a single HTTP round trip and returns the response.
+func (c *Client) send(req *http.Request) (*Response, error) {
 	// Perform request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doer.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform request: %w", err)
 	}
@@ -73,35 +152,46 @@ func (c *Client) doRequest(req *http.Request) (*Response, error) {
 	}
 
 	// Check for errors
-	if err := c.checkResponseError(response.StatusCode, response.Body); err != nil {
+	if err := c.checkResponseError(req, response); err != nil {
 		return response, err
 	}
 
 	return response, nil
 }
 
-// checkResponseError checks if the response indicates an error.
-func (c *Client) checkResponseError(statusCode int, body []byte) error {
-	switch statusCode {
+// checkResponseError checks if the response indicates an error. Errors are
+// returned as *APIError carrying the request and response details, and match
+// the sentinel for their status code with errors.Is.
+func (c *Client) checkResponseError(req *http.Request, resp *Response) error {
+	var sentinel error
+	switch resp.StatusCode {
 	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent:
 		return nil
 	case http.StatusBadRequest:
-		return newAPIError(statusCode, body)
+		sentinel = ErrBadRequest
 	case http.StatusUnauthorized:
-		return ErrUnauthorized
+		sentinel = ErrUnauthorized
 	case http.StatusNotFound:
-		return ErrVideoNotFound
+		sentinel = notFoundFor(req.URL.Path)
 	case http.StatusTooManyRequests:
-		return ErrRateLimited
+		sentinel = ErrRateLimited
 	case http.StatusInternalServerError:
-		return ErrInternalServer
+		sentinel = ErrInternalServer
 	case http.StatusServiceUnavailable:
-		return ErrServiceUnavailable
+		sentinel = ErrServiceUnavailable
 	case http.StatusForbidden:
-		return ErrForbidden
-	default:
-		return newAPIError(statusCode, body)
+		sentinel = ErrForbidden
 	}
+
+	apiErr := newAPIError(resp.StatusCode, resp.Body)
+	apiErr.err = sentinel
+	apiErr.Method = req.Method
+	apiErr.URL = redactURL(req.URL)
+	apiErr.Header = resp.Headers
+	apiErr.RequestID = requestID(resp.Headers)
+	apiErr.RetryAfter, _ = parseRetryAfter(resp.Headers.Get("Retry-After"), time.Now())
+
+	return apiErr
 }
 
 // buildURL constructs a full URL from the endpoint format and arguments.
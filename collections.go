@@ -0,0 +1,257 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrCollectionIDRequired is returned when an empty collection ID is
+	// passed to a collection method.
+	ErrCollectionIDRequired = errors.New("collection id is required")
+
+	// ErrCollectionNameRequired is returned when an empty name is passed to
+	// CreateCollection or UpdateCollection.
+	ErrCollectionNameRequired = errors.New("collection name is required")
+
+	// ErrCollectionNotFound is returned when a collection does not exist in
+	// the library. It matches ErrNotFound.
+	ErrCollectionNotFound = newNotFoundError("collection")
+)
+
+// Collection is a named group of videos within a library.
+type Collection struct {
+	// VideoLibraryID is the ID of the library the collection belongs to.
+	VideoLibraryID int64 `json:"videoLibraryId"`
+
+	// GUID is the unique ID of the collection. Pass it to WithCollectionID
+	// to create videos inside the collection.
+	GUID string `json:"guid"`
+
+	// Name is the display name of the collection.
+	Name string `json:"name"`
+
+	// VideoCount is the number of videos in the collection.
+	VideoCount int64 `json:"videoCount"`
+
+	// TotalSize is the storage used by the videos of the collection, in bytes.
+	TotalSize int64 `json:"totalSize"`
+
+	// PreviewVideoIDs is the comma-separated list of videos used for the
+	// collection preview.
+	PreviewVideoIDs string `json:"previewVideoIds"`
+
+	// PreviewImageURLs lists the thumbnail URLs of the preview videos. Only
+	// filled when thumbnails are requested.
+	PreviewImageURLs []string `json:"previewImageUrls"`
+}
+
+// ListCollectionsOptions filters and paginates the results of ListCollections.
+// Zero values are omitted from the request and fall back to the API defaults.
+type ListCollectionsOptions struct {
+	// Page is the 1-based page to fetch. Defaults to 1.
+	Page int
+
+	// ItemsPerPage is the number of collections per page, from 1 to 1000.
+	// Defaults to 100.
+	ItemsPerPage int
+
+	// Search filters collections by name.
+	Search string
+
+	// OrderBy sorts the results, e.g. OrderByDate. Defaults to OrderByDate.
+	OrderBy string
+
+	// IncludeThumbnails fills Collection.PreviewImageURLs.
+	IncludeThumbnails bool
+}
+
+// WithDeleteVideos also deletes every video of the collection when passed to
+// DeleteCollection. Without it the videos are kept and only detached from
+// the collection. It has no effect on DeleteVideo and DeleteVideos.
+func WithDeleteVideos() DeleteOption {
+	return func(o *deleteOptions) {
+		o.DeleteVideos = true
+	}
+}
+
+// CreateCollection creates a new collection in the library.
+func (c *Client) CreateCollection(ctx context.Context, name string) (*Collection, *Response, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, nil, ErrCollectionNameRequired
+	}
+
+	endpoint := c.buildURL("/library/%v/collections", c.libraryID)
+
+	bodyBuf, err := c.encodeJSON(map[string]string{"name": name})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.request(ctx, http.MethodPost, endpoint, bodyBuf, "application/json")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var collection Collection
+	if err := c.decodeJSON(resp.Body, &collection); err != nil {
+		return nil, resp, err
+	}
+
+	return &collection, resp, nil
+}
+
+// GetCollection fetches a single collection of the library. Returns
+// ErrCollectionNotFound if the collection does not exist.
+func (c *Client) GetCollection(ctx context.Context, collectionID string) (*Collection, *Response, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, nil, ErrCollectionIDRequired
+	}
+
+	endpoint := c.buildURL("/library/%v/collections/%v", c.libraryID, collectionID)
+
+	req, err := c.request(ctx, http.MethodGet, endpoint, nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var collection Collection
+	if err := c.decodeJSON(resp.Body, &collection); err != nil {
+		return nil, resp, err
+	}
+
+	return &collection, resp, nil
+}
+
+// ListCollections fetches a single page of collections in the library.
+//
+// Use AllCollections to walk every page without handling pagination manually.
+func (c *Client) ListCollections(ctx context.Context, opts ListCollectionsOptions) (*Page[Collection], *Response, error) {
+	endpoint := c.buildURL("/library/%v/collections", c.libraryID)
+
+	req, err := c.request(ctx, http.MethodGet, endpoint, nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var includeThumbnails *bool
+	if opts.IncludeThumbnails {
+		includeThumbnails = &opts.IncludeThumbnails
+	}
+
+	buildQuery(req).
+		setInt("page", opts.Page).
+		setInt("itemsPerPage", opts.ItemsPerPage).
+		setString("search", opts.Search).
+		setString("orderBy", opts.OrderBy).
+		setBool("includeThumbnails", includeThumbnails).
+		apply()
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var page Page[Collection]
+	if err := c.decodeJSON(resp.Body, &page); err != nil {
+		return nil, resp, err
+	}
+
+	return &page, resp, nil
+}
+
+// AllCollections returns an iterator over every collection matching opts,
+// starting at opts.Page. Pages are fetched lazily as the loop advances.
+func (c *Client) AllCollections(ctx context.Context, opts ListCollectionsOptions) iter.Seq2[Collection, error] {
+	if opts.ItemsPerPage < 1 {
+		opts.ItemsPerPage = DefaultItemsPerPage
+	}
+
+	return paginate(ctx, opts.Page, func(ctx context.Context, page int) (*Page[Collection], error) {
+		// Copy opts: the sequence may be ranged over concurrently.
+		o := opts
+		o.Page = page
+		result, _, err := c.ListCollections(ctx, o)
+		return result, err
+	})
+}
+
+// UpdateCollection renames a collection.
+func (c *Client) UpdateCollection(ctx context.Context, collectionID, name string) (*Response, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, ErrCollectionIDRequired
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrCollectionNameRequired
+	}
+
+	endpoint := c.buildURL("/library/%v/collections/%v", c.libraryID, collectionID)
+
+	bodyBuf, err := c.encodeJSON(map[string]string{"name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.request(ctx, http.MethodPost, endpoint, bodyBuf, "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DeleteCollection deletes a collection. Its videos are kept unless
+// WithDeleteVideos is set. With WithIgnoreNotFound, deleting a collection
+// that no longer exists returns a nil Response and nil error.
+func (c *Client) DeleteCollection(ctx context.Context, collectionID string, opts ...DeleteOption) (*Response, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, ErrCollectionIDRequired
+	}
+
+	options := &deleteOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	endpoint := c.buildURL("/library/%v/collections/%v", c.libraryID, collectionID)
+
+	req, err := c.request(ctx, http.MethodDelete, endpoint, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if options.DeleteVideos {
+		buildQuery(req).
+			setBool("deleteVideos", &options.DeleteVideos).
+			apply()
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		if options.IgnoreNotFound && errors.Is(err, ErrCollectionNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,375 @@
+package bunnystream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const sampleCollectionJSON = `{
+	"videoLibraryId": 123,
+	"guid": "col-1",
+	"name": "Customer A",
+	"videoCount": 4,
+	"totalSize": 1024,
+	"previewVideoIds": "a,b",
+	"previewImageUrls": ["https://example.com/a.jpg"]
+}`
+
+// -----------------------------------------------------------------------------
+// CreateCollection
+// -----------------------------------------------------------------------------
+
+func TestCreateCollection_SendsNameAndDecodes(t *testing.T) {
+	var gotMethod, gotPath string
+	var body map[string]json.RawMessage
+	capture := captureBody(t, &body)
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		capture(r)
+	}, http.StatusOK)
+	defer srv.Close()
+
+	c.CreateCollection(context.Background(), "Customer A")
+
+	if gotMethod != http.MethodPost {
+		t.Errorf("expected POST, got %q", gotMethod)
+	}
+	if want := "/library/123/collections"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+	if string(body["name"]) != `"Customer A"` {
+		t.Errorf("name = %s, want \"Customer A\"", body["name"])
+	}
+}
+
+func TestCreateCollection_DecodesCollection(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, sampleCollectionJSON)
+	defer srv.Close()
+
+	col, _, err := c.CreateCollection(context.Background(), "Customer A")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if col.GUID != "col-1" || col.Name != "Customer A" || col.VideoCount != 4 {
+		t.Errorf("collection = %+v", col)
+	}
+	if len(col.PreviewImageURLs) != 1 {
+		t.Errorf("PreviewImageURLs = %v", col.PreviewImageURLs)
+	}
+}
+
+func TestCreateCollection_EmptyName_ReturnsErrBeforeHTTP(t *testing.T) {
+	called := false
+	c, srv := inspectServer(t, func(r *http.Request) {
+		called = true
+	}, http.StatusOK)
+	defer srv.Close()
+
+	_, _, err := c.CreateCollection(context.Background(), " ")
+
+	if !errors.Is(err, ErrCollectionNameRequired) {
+		t.Errorf("expected ErrCollectionNameRequired, got %v", err)
+	}
+	if called {
+		t.Error("HTTP request was made despite empty name")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// GetCollection
+// -----------------------------------------------------------------------------
+
+func TestGetCollection_SendsGETAndDecodes(t *testing.T) {
+	var gotPath string
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotPath = r.URL.Path
+	}, http.StatusOK)
+	defer srv.Close()
+
+	c.GetCollection(context.Background(), "col-1")
+
+	if want := "/library/123/collections/col-1"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+
+	c2, srv2 := testServer(t, http.StatusOK, sampleCollectionJSON)
+	defer srv2.Close()
+
+	col, _, err := c2.GetCollection(context.Background(), "col-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if col.GUID != "col-1" {
+		t.Errorf("GUID = %q, want col-1", col.GUID)
+	}
+}
+
+func TestGetCollection_NotFound(t *testing.T) {
+	c, srv := testServer(t, http.StatusNotFound, "")
+	defer srv.Close()
+
+	_, _, err := c.GetCollection(context.Background(), "col-1")
+	if !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("expected ErrCollectionNotFound, got %v", err)
+	}
+	if errors.Is(err, ErrVideoNotFound) {
+		t.Error("a missing collection should not match ErrVideoNotFound")
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetCollection_EmptyID(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+
+	_, _, err := c.GetCollection(context.Background(), "")
+	if !errors.Is(err, ErrCollectionIDRequired) {
+		t.Errorf("expected ErrCollectionIDRequired, got %v", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// ListCollections / AllCollections
+// -----------------------------------------------------------------------------
+
+func TestListCollections_SendsQueryParams(t *testing.T) {
+	var gotPath string
+	var gotQuery map[string][]string
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.Query()
+	}, http.StatusOK)
+	defer srv.Close()
+
+	c.ListCollections(context.Background(), ListCollectionsOptions{
+		Page:              3,
+		ItemsPerPage:      10,
+		Search:            "cust",
+		OrderBy:           OrderByDate,
+		IncludeThumbnails: true,
+	})
+
+	if want := "/library/123/collections"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+
+	cases := []struct{ key, want string }{
+		{"page", "3"},
+		{"itemsPerPage", "10"},
+		{"search", "cust"},
+		{"orderBy", "date"},
+		{"includeThumbnails", "true"},
+	}
+	for _, c := range cases {
+		if got := gotQuery[c.key]; len(got) != 1 || got[0] != c.want {
+			t.Errorf("%s = %v, want %q", c.key, got, c.want)
+		}
+	}
+}
+
+func TestListCollections_OmitsIncludeThumbnailsWhenFalse(t *testing.T) {
+	var gotQuery string
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotQuery = r.URL.RawQuery
+	}, http.StatusOK)
+	defer srv.Close()
+
+	c.ListCollections(context.Background(), ListCollectionsOptions{})
+
+	if gotQuery != "" {
+		t.Errorf("expected empty query string, got %q", gotQuery)
+	}
+}
+
+func TestAllCollections_WalksEveryPage(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"totalItems":3,"currentPage":1,"itemsPerPage":2,"items":[{"guid":"a"},{"guid":"b"}]}`,
+		"2": `{"totalItems":3,"currentPage":2,"itemsPerPage":2,"items":[{"guid":"c"}]}`,
+	}
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	})
+	defer srv.Close()
+
+	var got []string
+	for col, err := range c.AllCollections(context.Background(), ListCollectionsOptions{ItemsPerPage: 2}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, col.GUID)
+	}
+
+	if len(got) != 3 || got[2] != "c" {
+		t.Errorf("collections = %v, want [a b c]", got)
+	}
+}
+
+func TestAllCollections_ReuseStartsOver(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"totalItems":3,"currentPage":1,"itemsPerPage":2,"items":[{"guid":"a"},{"guid":"b"}]}`,
+		"2": `{"totalItems":3,"currentPage":2,"itemsPerPage":2,"items":[{"guid":"c"}]}`,
+	}
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	})
+	defer srv.Close()
+
+	collections := c.AllCollections(context.Background(), ListCollectionsOptions{ItemsPerPage: 2})
+
+	for col := range collections {
+		if col.GUID == "c" {
+			break
+		}
+	}
+
+	var got []string
+	for col, err := range collections {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, col.GUID)
+	}
+
+	if len(got) != 3 || got[0] != "a" {
+		t.Errorf("collections of second walk = %v, want [a b c]", got)
+	}
+}
+
+func TestAllCollections_ConcurrentRanges(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"totalItems":3,"currentPage":1,"itemsPerPage":2,"items":[{"guid":"a"},{"guid":"b"}]}`,
+		"2": `{"totalItems":3,"currentPage":2,"itemsPerPage":2,"items":[{"guid":"c"}]}`,
+	}
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	})
+	defer srv.Close()
+
+	collections := c.AllCollections(context.Background(), ListCollectionsOptions{ItemsPerPage: 2})
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			var got []string
+			for col, err := range collections {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				got = append(got, col.GUID)
+			}
+			if strings.Join(got, " ") != "a b c" {
+				t.Errorf("collections = %v, want [a b c]", got)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// -----------------------------------------------------------------------------
+// UpdateCollection
+// -----------------------------------------------------------------------------
+
+func TestUpdateCollection_SendsPOSTWithName(t *testing.T) {
+	var gotMethod, gotPath string
+	var body map[string]json.RawMessage
+	capture := captureBody(t, &body)
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		capture(r)
+	}, http.StatusOK)
+	defer srv.Close()
+
+	if _, err := c.UpdateCollection(context.Background(), "col-1", "Renamed"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotMethod != http.MethodPost {
+		t.Errorf("expected POST, got %q", gotMethod)
+	}
+	if want := "/library/123/collections/col-1"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+	if string(body["name"]) != `"Renamed"` {
+		t.Errorf("name = %s, want \"Renamed\"", body["name"])
+	}
+}
+
+func TestUpdateCollection_Validation(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+
+	if _, err := c.UpdateCollection(context.Background(), "", "x"); !errors.Is(err, ErrCollectionIDRequired) {
+		t.Errorf("expected ErrCollectionIDRequired, got %v", err)
+	}
+	if _, err := c.UpdateCollection(context.Background(), "col-1", ""); !errors.Is(err, ErrCollectionNameRequired) {
+		t.Errorf("expected ErrCollectionNameRequired, got %v", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DeleteCollection
+// -----------------------------------------------------------------------------
+
+func TestDeleteCollection_SendsDELETE(t *testing.T) {
+	var gotMethod, gotPath, gotQuery string
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+	}, http.StatusOK)
+	defer srv.Close()
+
+	if _, err := c.DeleteCollection(context.Background(), "col-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotMethod != http.MethodDelete {
+		t.Errorf("expected DELETE, got %q", gotMethod)
+	}
+	if want := "/library/123/collections/col-1"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+	if gotQuery != "" {
+		t.Errorf("expected no query params by default, got %q", gotQuery)
+	}
+}
+
+func TestDeleteCollection_WithDeleteVideos(t *testing.T) {
+	var gotQuery string
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotQuery = r.URL.RawQuery
+	}, http.StatusOK)
+	defer srv.Close()
+
+	c.DeleteCollection(context.Background(), "col-1", WithDeleteVideos())
+
+	if gotQuery != "deleteVideos=true" {
+		t.Errorf("query = %q, want deleteVideos=true", gotQuery)
+	}
+}
+
+func TestDeleteCollection_EmptyID(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+
+	if _, err := c.DeleteCollection(context.Background(), ""); !errors.Is(err, ErrCollectionIDRequired) {
+		t.Errorf("expected ErrCollectionIDRequired, got %v", err)
+	}
+}
+
+func TestDeleteCollection_IgnoreNotFound(t *testing.T) {
+	c, srv := testServer(t, http.StatusNotFound, "")
+	defer srv.Close()
+
+	if _, err := c.DeleteCollection(context.Background(), "col-1", WithIgnoreNotFound()); err != nil {
+		t.Errorf("expected nil error with WithIgnoreNotFound, got %v", err)
+	}
+}
@@ -14,16 +14,32 @@ const (
 	DefaultTimeout    time.Duration = 60 * time.Second
 	DefaultUserAgent  string        = "bunnystream-go/0.1.0"
 	DefaultBaseURL    string        = "https://video.bunnycdn.com"
+
+	DefaultRetryWaitMin time.Duration = 500 * time.Millisecond
+	DefaultRetryWaitMax time.Duration = 30 * time.Second
 )
 
 // Config holds the configuration for the Bunny Stream client.
 type Config struct {
 	// Logger is the structured logger to use for logging information about API
-	// requests and responses.
+	// requests and responses. Every request attempt is logged with its method,
+	// path, status, duration, attempt number, bytes sent and received, and
+	// Bunny's request ID. The API key and signed tokens are never logged.
 	//
-	// This field is optional.
+	// This field is optional. Nothing is logged if nil.
 	Logger *slog.Logger
 
+	// LogLevel is the level successful requests are logged at.
+	//
+	// This field is optional. Defaults to slog.LevelDebug.
+	LogLevel slog.Leveler
+
+	// ErrorLogLevel is the level failed requests, including attempts that
+	// are retried, are logged at.
+	//
+	// This field is optional. Defaults to slog.LevelWarn.
+	ErrorLogLevel slog.Leveler
+
 	// APIKey is the API key for authenticating with Bunny Stream.
 	//
 	// SECURITY: This value must only be used server-side. Never ship it
@@ -91,12 +107,61 @@ type Config struct {
 	// This field is optional. If nil, a default client with DefaultTimeout will be used.
 	HTTPClient *http.Client
 
+	// Middleware wraps every request sent to the API, e.g. to inject tracing
+	// headers or audit calls. The first middleware is the outermost one. Each
+	// retry attempt runs through the chain again. The chain is built by
+	// NewClient; later changes have no effect.
+	//
+	// This field is optional.
+	Middleware []Middleware
+
 	// MaxRetries specifies the maximum number of times to retry a request if it
 	// fails due to rate limiting or temporary errors.
 	//
 	// This field is optional. Defaults to DefaultMaxRetries.
 	MaxRetries int
 
+	// RetryWaitMin is the base delay before the first retry. Each following
+	// retry doubles it, with random jitter applied.
+	//
+	// This field is optional. Defaults to DefaultRetryWaitMin.
+	RetryWaitMin time.Duration
+
+	// RetryWaitMax caps the delay between two retries. When the API asks
+	// for a longer wait through a Retry-After header, the request is not
+	// retried; its *APIError carries RetryAfter so the caller can reschedule.
+	//
+	// This field is optional. Defaults to DefaultRetryWaitMax.
+	RetryWaitMax time.Duration
+
+	// RateLimit paces API calls other than uploads with a token bucket
+	// shared by every goroutine using the client. Requests wait for a token,
+	// or until their context is done. The rate is halved whenever the API
+	// answers 429 Too Many Requests, and recovers as requests succeed.
+	//
+	// This field is optional. The zero value sends requests unthrottled.
+	RateLimit RateLimit
+
+	// UploadRateLimit is a separate budget for uploads made by UploadVideo,
+	// UploadFile and UploadVideoResumable, so that long uploads and metadata
+	// calls don't starve each other.
+	//
+	// This field is optional. The zero value sends uploads unthrottled.
+	UploadRateLimit RateLimit
+
+	// CircuitBreaker stops calling the API after a run of server errors or
+	// transport failures, returning ErrCircuitOpen until the API recovers.
+	//
+	// This field is optional. The zero value disables the breaker.
+	CircuitBreaker CircuitBreaker
+
+	// Metrics receives request counts, latencies, uploaded bytes and retries,
+	// keyed by endpoint templates such as "/library/{id}/videos/{guid}".
+	// NewExpvarMetrics provides an implementation based on expvar.
+	//
+	// This field is optional. Defaults to NopMetrics.
+	Metrics Metrics
+
 	// Timeout is the time limit for requests made by the client to the API.
 	//
 	// This field is optional. Defaults to DefaultTimeout.
@@ -111,6 +176,18 @@ func (c *Config) init() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.LogLevel == nil {
+		c.LogLevel = slog.LevelDebug
+	}
+
+	if c.ErrorLogLevel == nil {
+		c.ErrorLogLevel = slog.LevelWarn
+	}
+
+	if c.Metrics == nil {
+		c.Metrics = NopMetrics{}
+	}
+
 	if c.UserAgent == "" {
 		c.UserAgent = DefaultUserAgent
 	}
@@ -119,6 +196,18 @@ func (c *Config) init() {
 		c.MaxRetries = DefaultMaxRetries
 	}
 
+	if c.RetryWaitMin < 1 {
+		c.RetryWaitMin = DefaultRetryWaitMin
+	}
+
+	if c.RetryWaitMax < 1 {
+		c.RetryWaitMax = DefaultRetryWaitMax
+	}
+
+	if c.RetryWaitMax < c.RetryWaitMin {
+		c.RetryWaitMax = c.RetryWaitMin
+	}
+
 	if c.Timeout < 1 {
 		c.Timeout = DefaultTimeout
 	}
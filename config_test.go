@@ -92,6 +92,27 @@ func TestConfig_Init_DoesNotOverrideMaxRetries(t *testing.T) {
 	}
 }
 
+func TestConfig_Init_SetsDefaultRetryWaits(t *testing.T) {
+	cfg := &Config{}
+	cfg.init()
+
+	if cfg.RetryWaitMin != DefaultRetryWaitMin {
+		t.Errorf("RetryWaitMin = %v, want %v", cfg.RetryWaitMin, DefaultRetryWaitMin)
+	}
+	if cfg.RetryWaitMax != DefaultRetryWaitMax {
+		t.Errorf("RetryWaitMax = %v, want %v", cfg.RetryWaitMax, DefaultRetryWaitMax)
+	}
+}
+
+func TestConfig_Init_RetryWaitMaxNotBelowMin(t *testing.T) {
+	cfg := &Config{RetryWaitMin: 2 * time.Second, RetryWaitMax: time.Second}
+	cfg.init()
+
+	if cfg.RetryWaitMax != 2*time.Second {
+		t.Errorf("RetryWaitMax = %v, want %v", cfg.RetryWaitMax, 2*time.Second)
+	}
+}
+
 func TestConfig_Init_SetsDefaultTimeout(t *testing.T) {
 	cfg := &Config{}
 	cfg.init()
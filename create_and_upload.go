@@ -0,0 +1,55 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DeleteOnFailure makes CreateAndUpload delete the video object it created
+// when the upload fails, so the library does not fill up with empty videos.
+// It has no effect on the other upload methods.
+func DeleteOnFailure() UploadVideoOption {
+	return func(o *UploadVideoOptions) {
+		o.deleteOnFailure = true
+	}
+}
+
+// CreateAndUpload creates a video object titled title and uploads videoFile
+// to it in one call.
+//
+// createOpts configure the video object as in CreateVideoObject, uploadOpts
+// configure the upload as in UploadVideo. On success it returns the created
+// Video, whose GUID identifies it from now on, and the upload Response.
+//
+// If the upload fails, the created Video is still returned with the error so
+// the upload can be retried, unless DeleteOnFailure is set, in which case the
+// video object is deleted and a nil Video is returned.
+func (c *Client) CreateAndUpload(ctx context.Context, title string, videoFile io.Reader, createOpts []VideoOption, uploadOpts []UploadVideoOption) (*Video, *Response, error) {
+	options := &UploadVideoOptions{}
+	for _, opt := range uploadOpts {
+		opt(options)
+	}
+
+	video, _, err := c.CreateVideoObject(ctx, title, createOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.UploadVideo(ctx, video.GUID, videoFile, uploadOpts...)
+	if err == nil {
+		return video, resp, nil
+	}
+
+	if !options.deleteOnFailure {
+		return video, nil, err
+	}
+
+	// Clean up even when the upload failed because ctx was cancelled.
+	if _, delErr := c.DeleteVideo(context.WithoutCancel(ctx), video.GUID, WithIgnoreNotFound()); delErr != nil {
+		return nil, nil, errors.Join(err, fmt.Errorf("failed to delete video %s after failed upload: %w", video.GUID, delErr))
+	}
+
+	return nil, nil, err
+}
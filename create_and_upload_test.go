@@ -0,0 +1,142 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// workflowServer fakes the create, upload and delete endpoints. Uploads
+// answer with uploadStatus. Returns a client and the list of "METHOD path"
+// requests received.
+func workflowServer(t *testing.T, uploadStatus, deleteStatus int) (*Client, func() []string, func()) {
+	t.Helper()
+
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPost:
+			w.Write([]byte(`{"guid":"video-new","title":"My Video","status":0}`))
+		case http.MethodPut:
+			w.WriteHeader(uploadStatus)
+		case http.MethodDelete:
+			w.WriteHeader(deleteStatus)
+		}
+	})
+
+	get := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+
+	return c, get, srv.Close
+}
+
+func TestCreateAndUpload_Success(t *testing.T) {
+	c, calls, closeSrv := workflowServer(t, http.StatusOK, http.StatusOK)
+	defer closeSrv()
+
+	video, resp, err := c.CreateAndUpload(context.Background(), "My Video", strings.NewReader("data"),
+		[]VideoOption{WithCollectionID("col-1")},
+		[]UploadVideoOption{JITEnabled(true)},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if video.GUID != "video-new" || video.Title != "My Video" {
+		t.Errorf("video = %+v", video)
+	}
+	if resp == nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("expected upload response, got %+v", resp)
+	}
+
+	want := []string{"POST /library/123/videos", "PUT /library/123/videos/video-new"}
+	if got := calls(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestCreateAndUpload_FailureKeepsVideoByDefault(t *testing.T) {
+	c, calls, closeSrv := workflowServer(t, http.StatusBadRequest, http.StatusOK)
+	defer closeSrv()
+
+	video, _, err := c.CreateAndUpload(context.Background(), "My Video", strings.NewReader("data"), nil, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if video == nil || video.GUID != "video-new" {
+		t.Errorf("expected created video to be returned for a retry, got %+v", video)
+	}
+	for _, call := range calls() {
+		if strings.HasPrefix(call, http.MethodDelete) {
+			t.Errorf("video was deleted without DeleteOnFailure: %v", calls())
+		}
+	}
+}
+
+func TestCreateAndUpload_DeleteOnFailure(t *testing.T) {
+	c, calls, closeSrv := workflowServer(t, http.StatusBadRequest, http.StatusOK)
+	defer closeSrv()
+
+	video, _, err := c.CreateAndUpload(context.Background(), "My Video", strings.NewReader("data"), nil,
+		[]UploadVideoOption{DeleteOnFailure()})
+
+	if err == nil {
+		t.Fatal("expected upload error, got nil")
+	}
+	if video != nil {
+		t.Errorf("expected nil video after cleanup, got %+v", video)
+	}
+
+	got := calls()
+	if last := got[len(got)-1]; last != "DELETE /library/123/videos/video-new" {
+		t.Errorf("last call = %q, want the orphaned video to be deleted", last)
+	}
+}
+
+func TestCreateAndUpload_DeleteOnFailureReportsCleanupError(t *testing.T) {
+	c, _, closeSrv := workflowServer(t, http.StatusBadRequest, http.StatusForbidden)
+	defer closeSrv()
+
+	_, _, err := c.CreateAndUpload(context.Background(), "My Video", strings.NewReader("data"), nil,
+		[]UploadVideoOption{DeleteOnFailure()})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected the upload error to be kept, got %v", err)
+	}
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected the cleanup error to be joined, got %v", err)
+	}
+}
+
+func TestCreateAndUpload_CreateFailureSkipsUpload(t *testing.T) {
+	c, calls, closeSrv := workflowServer(t, http.StatusOK, http.StatusOK)
+	defer closeSrv()
+
+	_, _, err := c.CreateAndUpload(context.Background(), "", strings.NewReader("data"), nil, nil)
+
+	if !errors.Is(err, ErrTitleRequired) {
+		t.Errorf("expected ErrTitleRequired, got %v", err)
+	}
+	if got := calls(); len(got) != 0 {
+		t.Errorf("expected no requests, got %v", got)
+	}
+}
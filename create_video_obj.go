@@ -33,15 +33,15 @@ func WithThumbnailTime(time string) VideoOption {
 //   - collectionId: The UUID of the collection to which the video belongs (Optional).
 //   - thumbnailTime: The timestamp (in seconds/format) to capture the preview image (Optional).
 //
-// Returns a Response pointer containing the server's metadata or an Error
+// Returns the decoded Video together with the raw Response, or an Error
 // if the title is empty.
-func (c *Client) CreateVideoObject(ctx context.Context, title string, opts ...VideoOption) (*Response, error) {
+func (c *Client) CreateVideoObject(ctx context.Context, title string, opts ...VideoOption) (*Video, *Response, error) {
 	endpoint := c.buildURL("/library/%v/videos", c.libraryID)
 
 	body := make(map[string]string, 1)
 
 	if strings.TrimSpace(title) == "" {
-		return nil, ErrTitleRequired
+		return nil, nil, ErrTitleRequired
 	}
 	body["title"] = title
 
@@ -61,18 +61,23 @@ func (c *Client) CreateVideoObject(ctx context.Context, title string, opts ...Vi
 
 	bodyBuf, err := c.encodeJSON(body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req, err := c.request(ctx, http.MethodPost, endpoint, bodyBuf, "application/json")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	resp, err := c.doRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return resp, nil
+	var video Video
+	if err := c.decodeJSON(resp.Body, &video); err != nil {
+		return nil, resp, err
+	}
+
+	return &video, resp, nil
 }
@@ -0,0 +1,113 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type deleteOptions struct {
+	IgnoreNotFound bool
+	DeleteVideos   bool
+}
+
+// DeleteOption configures DeleteVideo, DeleteVideos and DeleteCollection.
+type DeleteOption func(*deleteOptions)
+
+// WithIgnoreNotFound treats a resource that no longer exists as successfully
+// deleted instead of returning a not-found error. Useful when retrying cleanup
+// jobs that may have partially run before.
+func WithIgnoreNotFound() DeleteOption {
+	return func(o *deleteOptions) {
+		o.IgnoreNotFound = true
+	}
+}
+
+// DeleteVideo permanently deletes a video and all of its encoded files.
+//
+// Returns ErrVideoNotFound if the video does not exist, unless
+// WithIgnoreNotFound is set, in which case a nil Response and nil error are
+// returned.
+func (c *Client) DeleteVideo(ctx context.Context, videoID string, opts ...DeleteOption) (*Response, error) {
+	if strings.TrimSpace(videoID) == "" {
+		return nil, ErrVideoIDRequired
+	}
+
+	options := &deleteOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	endpoint := c.buildURL("/library/%v/videos/%v", c.libraryID, videoID)
+
+	req, err := c.request(ctx, http.MethodDelete, endpoint, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		if options.IgnoreNotFound && errors.Is(err, ErrVideoNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DeleteVideos deletes many videos, running at most concurrency deletions at
+// the same time. A concurrency below 1 deletes one video at a time.
+//
+// The returned map holds an entry for every distinct ID in ids: nil when the
+// video was deleted, or the error that prevented it. Once ctx is done, IDs
+// that were not started yet report the context error.
+func (c *Client) DeleteVideos(ctx context.Context, ids []string, concurrency int, opts ...DeleteOption) map[string]error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]error, len(ids))
+		sem     = make(chan struct{}, concurrency)
+	)
+
+	for _, id := range ids {
+		mu.Lock()
+		_, seen := results[id]
+		results[id] = nil
+		mu.Unlock()
+		if seen {
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			results[id] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			_, err := c.DeleteVideo(ctx, id, opts...)
+
+			mu.Lock()
+			results[id] = err
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
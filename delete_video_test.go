@@ -0,0 +1,163 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// DeleteVideo
+// -----------------------------------------------------------------------------
+
+func TestDeleteVideo_SendsDELETEToVideoPath(t *testing.T) {
+	var gotMethod, gotPath string
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+	}, http.StatusOK)
+	defer srv.Close()
+
+	if _, err := c.DeleteVideo(context.Background(), "video-abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotMethod != http.MethodDelete {
+		t.Errorf("expected DELETE, got %q", gotMethod)
+	}
+	if want := "/library/123/videos/video-abc"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+}
+
+func TestDeleteVideo_EmptyVideoID_ReturnsErrBeforeHTTP(t *testing.T) {
+	called := false
+	c, srv := inspectServer(t, func(r *http.Request) {
+		called = true
+	}, http.StatusOK)
+	defer srv.Close()
+
+	_, err := c.DeleteVideo(context.Background(), "")
+
+	if !errors.Is(err, ErrVideoIDRequired) {
+		t.Errorf("expected ErrVideoIDRequired, got %v", err)
+	}
+	if called {
+		t.Error("HTTP request was made despite empty videoID")
+	}
+}
+
+func TestDeleteVideo_NotFound(t *testing.T) {
+	c, srv := testServer(t, http.StatusNotFound, "")
+	defer srv.Close()
+
+	_, err := c.DeleteVideo(context.Background(), "video-abc")
+	if !errors.Is(err, ErrVideoNotFound) {
+		t.Errorf("expected ErrVideoNotFound, got %v", err)
+	}
+}
+
+func TestDeleteVideo_IgnoreNotFound(t *testing.T) {
+	c, srv := testServer(t, http.StatusNotFound, "")
+	defer srv.Close()
+
+	if _, err := c.DeleteVideo(context.Background(), "video-abc", WithIgnoreNotFound()); err != nil {
+		t.Errorf("expected nil error with WithIgnoreNotFound, got %v", err)
+	}
+}
+
+func TestDeleteVideo_IgnoreNotFoundKeepsOtherErrors(t *testing.T) {
+	c, srv := testServer(t, http.StatusUnauthorized, "")
+	defer srv.Close()
+
+	_, err := c.DeleteVideo(context.Background(), "video-abc", WithIgnoreNotFound())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DeleteVideos
+// -----------------------------------------------------------------------------
+
+func TestDeleteVideos_ReportsPerIDResults(t *testing.T) {
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer srv.Close()
+
+	results := c.DeleteVideos(context.Background(), []string{"a", "missing", "b", "a"}, 2)
+
+	if len(results) != 3 {
+		t.Fatalf("results = %v, want 3 entries", results)
+	}
+	if results["a"] != nil || results["b"] != nil {
+		t.Errorf("expected a and b to succeed, got %v", results)
+	}
+	if !errors.Is(results["missing"], ErrVideoNotFound) {
+		t.Errorf("missing = %v, want ErrVideoNotFound", results["missing"])
+	}
+
+	results = c.DeleteVideos(context.Background(), []string{"missing"}, 1, WithIgnoreNotFound())
+	if err, ok := results["missing"]; !ok || err != nil {
+		t.Errorf("missing with WithIgnoreNotFound = %v (present %v), want nil", err, ok)
+	}
+}
+
+func TestDeleteVideos_RespectsConcurrencyLimit(t *testing.T) {
+	var (
+		mu             sync.Mutex
+		active, peak   int
+		totalRequested atomic.Int32
+	)
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		totalRequested.Add(1)
+		mu.Lock()
+		active++
+		peak = max(peak, active)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+	defer srv.Close()
+
+	ids := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+	c.DeleteVideos(context.Background(), ids, 3)
+
+	if n := totalRequested.Load(); n != 10 {
+		t.Errorf("requests = %d, want 10", n)
+	}
+	if peak > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", peak)
+	}
+}
+
+func TestDeleteVideos_CancelledContext(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, "")
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := c.DeleteVideos(ctx, []string{"a", "b", "c"}, 1)
+
+	for id, err := range results {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: expected context.Canceled, got %v", id, err)
+		}
+	}
+}
@@ -1,8 +1,14 @@
 package bunnystream
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 )
 
 // Predefined errors.
@@ -12,7 +18,8 @@ var (
 	ErrLibraryIDRequired  = errors.New("library id required")
 	ErrInvalidMaxRetries  = errors.New("max retries must be greater than 0")
 	ErrInvalidTimeout     = errors.New("timeout must be greater than 0")
-	ErrVideoNotFound      = errors.New("video not found")
+	ErrNotFound           = errors.New("not found")
+	ErrVideoNotFound      = newNotFoundError("video")
 	ErrUnauthorized       = errors.New("unauthorized - check your API key")
 	ErrRateLimited        = errors.New("rate limited - too many requests")
 	ErrBadRequest         = errors.New("bad request - check your input")
@@ -24,36 +31,250 @@ var (
 	ErrForbidden          = errors.New("forbidden - insufficient permissions or invalid token")
 )
 
+// notFoundError reports that a specific kind of resource does not exist.
+// Every notFoundError matches ErrNotFound with errors.Is.
+type notFoundError struct {
+	resource string
+}
+
+func newNotFoundError(resource string) error {
+	return &notFoundError{resource: resource}
+}
+
+// Error implements the error interface.
+func (e *notFoundError) Error() string {
+	return e.resource + " not found"
+}
+
+// Is reports whether target is ErrNotFound.
+func (e *notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
 // APIError represents an error response from the Bunny Stream API.
+//
+// Bunny reports errors either as {"success":false,"message":"...","statusCode":400},
+// as {"ErrorKey":"...","Field":"...","Message":"..."}, or as ASP.NET validation
+// problems with a map of field errors. Whatever is recognized is parsed into
+// the fields below; Body always keeps the raw response.
+//
+// Errors for well-known status codes wrap the matching sentinel, so
+// errors.Is(err, ErrRateLimited) and friends keep working.
 type APIError struct {
 	StatusCode int
-	Message    string
-	Body       string
+
+	// Method and URL identify the failed request. Credentials in the URL
+	// query are redacted.
+	Method string
+	URL    string
+
+	// RequestID is the request ID Bunny assigned to the call, if any.
+	RequestID string
+
+	// RetryAfter is the delay requested by a Retry-After header, or zero.
+	RetryAfter time.Duration
+
+	// Header holds the response headers.
+	Header http.Header
+
+	// Message is the human readable error message. For bodies that are not
+	// JSON it holds the beginning of the raw body.
+	Message string
+
+	// ErrorKey is Bunny's machine readable error code, e.g. "video.not_found".
+	ErrorKey string
+
+	// Field is the request field the error refers to, if any.
+	Field string
+
+	// ValidationErrors maps request fields to their validation messages.
+	ValidationErrors map[string][]string
+
+	Body string
+
+	// err is the sentinel matching StatusCode, if any.
+	err error
 }
 
 // Error implements the error interface.
 func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.Method != "" && e.URL != "" {
+		fmt.Fprintf(&b, "%s %s: ", e.Method, e.URL)
+	}
+	fmt.Fprintf(&b, "bunny stream api error (status %d)", e.StatusCode)
+
+	if e.err != nil {
+		b.WriteString(": ")
+		b.WriteString(e.err.Error())
+	}
 	if e.Message != "" {
-		return fmt.Sprintf("bunny stream api error (status %d): %s", e.StatusCode, e.Message)
+		b.WriteString(": ")
+		b.WriteString(e.Message)
+	}
+	if e.Field != "" {
+		fmt.Fprintf(&b, " (field %s)", e.Field)
+	}
+
+	fields := make([]string, 0, len(e.ValidationErrors))
+	for field := range e.ValidationErrors {
+		fields = append(fields, field)
 	}
-	return fmt.Sprintf("bunny stream api error (status %d)", e.StatusCode)
+	slices.Sort(fields)
+	for _, field := range fields {
+		fmt.Fprintf(&b, "; %s: %s", field, strings.Join(e.ValidationErrors[field], " "))
+	}
+
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " [request id %s]", e.RequestID)
+	}
+
+	return b.String()
+}
+
+// Unwrap returns the sentinel error matching the status code, if any.
+func (e *APIError) Unwrap() error {
+	return e.err
 }
 
 // newAPIError creates a new APIError from status code and response body.
 func newAPIError(statusCode int, body []byte) *APIError {
-	return &APIError{
+	e := &APIError{
 		StatusCode: statusCode,
 		Body:       string(body),
-		Message:    parseErrorMessage(body),
 	}
+	e.parseBody(body)
+	return e
+}
+
+// maxRawMessageLen bounds the Message taken from a body that is not JSON.
+const maxRawMessageLen = 200
+
+// errorBody covers the error shapes returned by Bunny. Field names are
+// matched case-insensitively, so both "message" and "Message" decode.
+type errorBody struct {
+	Message  string          `json:"message"`
+	ErrorKey string          `json:"errorKey"`
+	Field    string          `json:"field"`
+	Title    string          `json:"title"`
+	Detail   string          `json:"detail"`
+	Errors   json.RawMessage `json:"errors"`
+}
+
+// parseBody fills the message fields of e from an error response body,
+// falling back to the truncated raw body when it is not a JSON object.
+func (e *APIError) parseBody(body []byte) {
+	var parsed errorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		e.Message = truncate(strings.TrimSpace(string(body)), maxRawMessageLen)
+		return
+	}
+
+	e.ErrorKey = parsed.ErrorKey
+	e.Field = parsed.Field
+	e.ValidationErrors = parseValidationErrors(parsed.Errors)
+
+	switch {
+	case parsed.Message != "":
+		e.Message = parsed.Message
+	case parsed.Detail != "":
+		e.Message = parsed.Detail
+	default:
+		e.Message = parsed.Title
+	}
+}
+
+// parseValidationErrors decodes the "errors" member of an ASP.NET problem,
+// which maps fields to one or several messages.
+func parseValidationErrors(raw json.RawMessage) map[string][]string {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var many map[string][]string
+	if err := json.Unmarshal(raw, &many); err == nil && len(many) > 0 {
+		return many
+	}
+
+	var single map[string]string
+	if err := json.Unmarshal(raw, &single); err == nil && len(single) > 0 {
+		many = make(map[string][]string, len(single))
+		for field, msg := range single {
+			many[field] = []string{msg}
+		}
+		return many
+	}
+
+	return nil
+}
+
+// notFoundErrors maps the path segment naming a resource to the error
+// returned when that resource does not exist.
+var notFoundErrors = map[string]error{
+	"videos":      ErrVideoNotFound,
+	"collections": ErrCollectionNotFound,
+}
+
+// notFoundFor returns the not-found error for the resource addressed by an
+// endpoint path, e.g. ErrCollectionNotFound for
+// /library/123/collections/abc. The innermost known resource wins; paths
+// naming none return ErrNotFound.
+func notFoundFor(path string) error {
+	err := ErrNotFound
+	for segment := range strings.SplitSeq(path, "/") {
+		if resource, ok := notFoundErrors[segment]; ok {
+			err = resource
+		}
+	}
+	return err
+}
+
+// requestIDHeaders lists the response headers carrying a request ID, in
+// order of preference.
+var requestIDHeaders = []string{"CDN-RequestId", "X-Request-Id", "Request-Id"}
+
+// requestID returns the request ID found in h, if any.
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := h.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// sensitiveParams lists query parameters that carry credentials.
+var sensitiveParams = []string{"accesskey", "token", "signature", "key", "secret", "password"}
+
+// redactURL returns u as a string with credentials in the user info and
+// query replaced by "REDACTED".
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User("REDACTED")
+	}
+
+	if redacted.RawQuery != "" {
+		query := redacted.Query()
+		for name := range query {
+			if slices.Contains(sensitiveParams, strings.ToLower(name)) {
+				query.Set(name, "REDACTED")
+			}
+		}
+		redacted.RawQuery = query.Encode()
+	}
+
+	return redacted.String()
 }
 
-// parseErrorMessage attempts to extract error message from response body.
-func parseErrorMessage(body []byte) string {
-	// For now, just return the raw body
-	// TODO: Parse JSON error responses if Bunny returns structured errors
-	if len(body) > 200 {
-		return string(body[:200]) + "..."
+// truncate shortens s to at most n bytes, marking the cut with "...".
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
 	}
-	return string(body)
+	return s[:n] + "..."
 }
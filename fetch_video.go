@@ -0,0 +1,102 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrSourceURLRequired is returned when FetchVideo is called with an
+	// empty or non-HTTP(S) source URL.
+	ErrSourceURLRequired = errors.New("source url is required and must be http or https")
+
+	// ErrFetchVideoIDMissing is returned when Bunny accepts a fetch request
+	// without returning the ID of the created video.
+	ErrFetchVideoIDMissing = errors.New("fetch response did not include a video id")
+)
+
+// FetchOptions configures FetchVideo. Zero values are omitted.
+type FetchOptions struct {
+	// Title is the display name of the created video. Defaults to the
+	// file name of the source URL.
+	Title string
+
+	// CollectionID is the collection to create the video in.
+	CollectionID string
+
+	// ThumbnailTime is the timestamp to capture the thumbnail from.
+	ThumbnailTime string
+
+	// Headers are sent by Bunny when downloading the source URL, e.g. an
+	// Authorization header for a private origin.
+	Headers map[string]string
+}
+
+// fetchRequest is the JSON body of the fetch endpoint.
+type fetchRequest struct {
+	URL     string            `json:"url"`
+	Title   string            `json:"title,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// fetchResponse is the JSON body returned by the fetch endpoint.
+type fetchResponse struct {
+	ID   string `json:"id"`
+	GUID string `json:"guid"`
+}
+
+// FetchVideo asks Bunny to download a video from sourceURL server-side,
+// such as a presigned S3 link, instead of uploading its bytes from here.
+//
+// Returns the ID of the created video. The download and encoding continue in
+// the background; track them with GetVideo.
+func (c *Client) FetchVideo(ctx context.Context, sourceURL string, opts FetchOptions) (string, *Response, error) {
+	u, err := url.Parse(strings.TrimSpace(sourceURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", nil, ErrSourceURLRequired
+	}
+
+	endpoint := c.buildURL("/library/%v/videos/fetch", c.libraryID)
+
+	bodyBuf, err := c.encodeJSON(fetchRequest{
+		URL:     u.String(),
+		Title:   opts.Title,
+		Headers: opts.Headers,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	req, err := c.request(ctx, http.MethodPost, endpoint, bodyBuf, "application/json")
+	if err != nil {
+		return "", nil, err
+	}
+
+	buildQuery(req).
+		setString("collectionId", opts.CollectionID).
+		setString("thumbnailTime", opts.ThumbnailTime).
+		apply()
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var result fetchResponse
+	if err := c.decodeJSON(resp.Body, &result); err != nil {
+		return "", resp, err
+	}
+
+	id := result.ID
+	if id == "" {
+		id = result.GUID
+	}
+	if id == "" {
+		return "", resp, ErrFetchVideoIDMissing
+	}
+
+	return id, resp, nil
+}
@@ -0,0 +1,92 @@
+package bunnystream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestFetchVideo_SendsRequest(t *testing.T) {
+	var (
+		gotMethod, gotPath string
+		gotQuery           map[string][]string
+		gotBody            fetchRequest
+	)
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		gotQuery = r.URL.Query()
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotBody)
+		w.Write([]byte(`{"success":true,"message":"OK","statusCode":200,"id":"video-new"}`))
+	})
+	defer srv.Close()
+
+	id, _, err := c.FetchVideo(context.Background(), "https://bucket.s3.amazonaws.com/v.mp4?X-Amz-Signature=abc", FetchOptions{
+		Title:         "Partner Video",
+		CollectionID:  "col-1",
+		ThumbnailTime: "5000",
+		Headers:       map[string]string{"Authorization": "Bearer x"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if id != "video-new" {
+		t.Errorf("id = %q, want video-new", id)
+	}
+	if gotMethod != http.MethodPost {
+		t.Errorf("expected POST, got %q", gotMethod)
+	}
+	if want := "/library/123/videos/fetch"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+	if gotQuery["collectionId"][0] != "col-1" || gotQuery["thumbnailTime"][0] != "5000" {
+		t.Errorf("query = %v", gotQuery)
+	}
+	if gotBody.URL != "https://bucket.s3.amazonaws.com/v.mp4?X-Amz-Signature=abc" {
+		t.Errorf("body url = %q", gotBody.URL)
+	}
+	if gotBody.Title != "Partner Video" || gotBody.Headers["Authorization"] != "Bearer x" {
+		t.Errorf("body = %+v", gotBody)
+	}
+}
+
+func TestFetchVideo_FallsBackToGUID(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, `{"guid":"video-guid"}`)
+	defer srv.Close()
+
+	id, _, err := c.FetchVideo(context.Background(), "https://example.com/v.mp4", FetchOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "video-guid" {
+		t.Errorf("id = %q, want video-guid", id)
+	}
+}
+
+func TestFetchVideo_MissingID(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, `{"success":true}`)
+	defer srv.Close()
+
+	_, resp, err := c.FetchVideo(context.Background(), "https://example.com/v.mp4", FetchOptions{})
+	if !errors.Is(err, ErrFetchVideoIDMissing) {
+		t.Errorf("expected ErrFetchVideoIDMissing, got %v", err)
+	}
+	if resp == nil {
+		t.Error("expected raw response alongside the error")
+	}
+}
+
+func TestFetchVideo_InvalidSourceURL(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+
+	for _, u := range []string{"", "   ", "not a url", "ftp://example.com/v.mp4", "https://"} {
+		if _, _, err := c.FetchVideo(context.Background(), u, FetchOptions{}); !errors.Is(err, ErrSourceURLRequired) {
+			t.Errorf("FetchVideo(%q): expected ErrSourceURLRequired, got %v", u, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package bunnystream
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// GetVideo fetches the metadata of a single video in the library.
+//
+// Returns the decoded Video together with the raw Response, or
+// ErrVideoNotFound if the video does not exist in this library.
+func (c *Client) GetVideo(ctx context.Context, videoID string) (*Video, *Response, error) {
+	if strings.TrimSpace(videoID) == "" {
+		return nil, nil, ErrVideoIDRequired
+	}
+
+	endpoint := c.buildURL("/library/%v/videos/%v", c.libraryID, videoID)
+
+	req, err := c.request(ctx, http.MethodGet, endpoint, nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var video Video
+	if err := c.decodeJSON(resp.Body, &video); err != nil {
+		return nil, resp, err
+	}
+
+	return &video, resp, nil
+}
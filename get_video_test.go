@@ -0,0 +1,178 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+const sampleVideoJSON = `{
+	"videoLibraryId": 123,
+	"guid": "video-abc",
+	"title": "My Video",
+	"dateUploaded": "2024-03-14T10:09:29.057",
+	"views": 42,
+	"length": 125,
+	"status": 4,
+	"width": 1920,
+	"height": 1080,
+	"availableResolutions": "360p,720p,1080p",
+	"encodeProgress": 100,
+	"storageSize": 73400320,
+	"captions": [{"srclang": "en", "label": "English"}],
+	"collectionId": "col-1",
+	"thumbnailFileName": "thumbnail.jpg",
+	"chapters": [{"title": "Intro", "start": 0, "end": 30}],
+	"moments": [{"label": "Goal", "timestamp": 61}],
+	"metaTags": [{"property": "sha256", "value": "abc"}]
+}`
+
+// -----------------------------------------------------------------------------
+// GetVideo
+// -----------------------------------------------------------------------------
+
+func TestGetVideo_SendsGETToVideoPath(t *testing.T) {
+	var gotMethod, gotPath string
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+	}, http.StatusOK)
+	defer srv.Close()
+
+	c.GetVideo(context.Background(), "video-abc")
+
+	if gotMethod != http.MethodGet {
+		t.Errorf("expected GET, got %q", gotMethod)
+	}
+	if want := "/library/123/videos/video-abc"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+}
+
+func TestGetVideo_DecodesVideo(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, sampleVideoJSON)
+	defer srv.Close()
+
+	video, resp, err := c.GetVideo(context.Background(), "video-abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected raw 200 response, got %+v", resp)
+	}
+
+	if video.GUID != "video-abc" || video.Title != "My Video" {
+		t.Errorf("guid/title = %q/%q, want video-abc/My Video", video.GUID, video.Title)
+	}
+	if video.Length != 125 || video.Width != 1920 || video.Height != 1080 {
+		t.Errorf("length/width/height = %d/%d/%d", video.Length, video.Width, video.Height)
+	}
+	if video.StorageSize != 73400320 || video.Views != 42 || video.EncodeProgress != 100 {
+		t.Errorf("storageSize/views/encodeProgress = %d/%d/%d", video.StorageSize, video.Views, video.EncodeProgress)
+	}
+	if video.CollectionID != "col-1" {
+		t.Errorf("CollectionID = %q, want col-1", video.CollectionID)
+	}
+	if len(video.Captions) != 1 || video.Captions[0].SrcLang != "en" {
+		t.Errorf("Captions = %+v", video.Captions)
+	}
+	if len(video.Chapters) != 1 || video.Chapters[0].End != 30 {
+		t.Errorf("Chapters = %+v", video.Chapters)
+	}
+	if len(video.Moments) != 1 || video.Moments[0].Timestamp != 61 {
+		t.Errorf("Moments = %+v", video.Moments)
+	}
+	if len(video.MetaTags) != 1 || video.MetaTags[0].Value != "abc" {
+		t.Errorf("MetaTags = %+v", video.MetaTags)
+	}
+}
+
+func TestGetVideo_EmptyVideoID_ReturnsErrBeforeHTTP(t *testing.T) {
+	called := false
+	c, srv := inspectServer(t, func(r *http.Request) {
+		called = true
+	}, http.StatusOK)
+	defer srv.Close()
+
+	_, _, err := c.GetVideo(context.Background(), " ")
+
+	if !errors.Is(err, ErrVideoIDRequired) {
+		t.Errorf("expected ErrVideoIDRequired, got %v", err)
+	}
+	if called {
+		t.Error("HTTP request was made despite empty videoID")
+	}
+}
+
+func TestGetVideo_NotFound(t *testing.T) {
+	c, srv := testServer(t, http.StatusNotFound, "")
+	defer srv.Close()
+
+	video, _, err := c.GetVideo(context.Background(), "missing")
+
+	if !errors.Is(err, ErrVideoNotFound) {
+		t.Errorf("expected ErrVideoNotFound, got %v", err)
+	}
+	if video != nil {
+		t.Errorf("expected nil video on error, got %+v", video)
+	}
+}
+
+func TestGetVideo_InvalidJSON(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, `not json`)
+	defer srv.Close()
+
+	_, resp, err := c.GetVideo(context.Background(), "video-abc")
+
+	if err == nil {
+		t.Fatal("expected decode error, got nil")
+	}
+	if resp == nil {
+		t.Error("expected raw response to be returned alongside decode error")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// CreateVideoObject — decoding
+// -----------------------------------------------------------------------------
+
+func TestCreateVideoObject_DecodesVideo(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, sampleVideoJSON)
+	defer srv.Close()
+
+	video, resp, err := c.CreateVideoObject(context.Background(), "My Video")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp == nil {
+		t.Fatal("expected raw response, got nil")
+	}
+	if video.GUID != "video-abc" {
+		t.Errorf("GUID = %q, want video-abc", video.GUID)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Video.Resolutions
+// -----------------------------------------------------------------------------
+
+func TestVideoResolutions(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Resolution
+	}{
+		{"", nil},
+		{"720p", []Resolution{Res720p}},
+		{"360p, 720p,1080p", []Resolution{Res360p, Res720p, Res1080p}},
+		{"240p,,480p", []Resolution{Res240p, Res480p}},
+	}
+
+	for _, tt := range tests {
+		v := &Video{AvailableResolutions: tt.in}
+		if got := v.Resolutions(); !slices.Equal(got, tt.want) {
+			t.Errorf("Resolutions(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package bunnystream
+
+import (
+	"context"
+	"iter"
+	"net/http"
+)
+
+// Sort orders accepted by ListVideosOptions.OrderBy.
+const (
+	OrderByDate  = "date"
+	OrderByTitle = "title"
+)
+
+// ListVideosOptions filters and paginates the results of ListVideos.
+// Zero values are omitted from the request and fall back to the API defaults.
+type ListVideosOptions struct {
+	// Page is the 1-based page to fetch. Defaults to 1.
+	Page int
+
+	// ItemsPerPage is the number of videos per page, from 1 to 1000.
+	// Defaults to 100.
+	ItemsPerPage int
+
+	// Search filters videos by title.
+	Search string
+
+	// Collection restricts the results to a single collection ID.
+	Collection string
+
+	// OrderBy sorts the results, e.g. OrderByDate or OrderByTitle.
+	// Defaults to OrderByDate.
+	OrderBy string
+}
+
+// ListVideos fetches a single page of videos in the library.
+//
+// Use AllVideos to walk every page without handling pagination manually.
+func (c *Client) ListVideos(ctx context.Context, opts ListVideosOptions) (*Page[Video], *Response, error) {
+	endpoint := c.buildURL("/library/%v/videos", c.libraryID)
+
+	req, err := c.request(ctx, http.MethodGet, endpoint, nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buildQuery(req).
+		setInt("page", opts.Page).
+		setInt("itemsPerPage", opts.ItemsPerPage).
+		setString("search", opts.Search).
+		setString("collection", opts.Collection).
+		setString("orderBy", opts.OrderBy).
+		apply()
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var page Page[Video]
+	if err := c.decodeJSON(resp.Body, &page); err != nil {
+		return nil, resp, err
+	}
+
+	return &page, resp, nil
+}
+
+// AllVideos returns an iterator over every video matching opts, starting at
+// opts.Page. Pages are fetched lazily as the loop advances, so only one page
+// is held in memory at a time. Breaking out of the loop stops fetching.
+//
+//	for video, err := range client.AllVideos(ctx, bunnystream.ListVideosOptions{}) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(video.GUID)
+//	}
+func (c *Client) AllVideos(ctx context.Context, opts ListVideosOptions) iter.Seq2[Video, error] {
+	if opts.ItemsPerPage < 1 {
+		opts.ItemsPerPage = DefaultItemsPerPage
+	}
+
+	return paginate(ctx, opts.Page, func(ctx context.Context, page int) (*Page[Video], error) {
+		// Copy opts: the sequence may be ranged over concurrently.
+		o := opts
+		o.Page = page
+		result, _, err := c.ListVideos(ctx, o)
+		return result, err
+	})
+}
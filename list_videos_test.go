@@ -0,0 +1,265 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// pagedVideoServer creates a fake HTTP server that serves total videos split
+// into pages according to the page and itemsPerPage query params. Returns a
+// client configured to talk to it and a counter of requests served.
+func pagedVideoServer(t *testing.T, total int) (*Client, *httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("itemsPerPage"))
+
+		items := "["
+		for i := (page - 1) * perPage; i < min(page*perPage, total); i++ {
+			if i > (page-1)*perPage {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"guid":"video-%d"}`, i)
+		}
+		items += "]"
+
+		fmt.Fprintf(w, `{"totalItems":%d,"currentPage":%d,"itemsPerPage":%d,"items":%s}`,
+			total, page, perPage, items)
+	}))
+
+	client := mustNewClient(t, &Config{
+		APIKey:     "test-key",
+		LibraryID:  "123",
+		BaseURL:    srv.URL,
+		HTTPClient: srv.Client(),
+	})
+
+	return client, srv, &calls
+}
+
+// -----------------------------------------------------------------------------
+// ListVideos
+// -----------------------------------------------------------------------------
+
+func TestListVideos_SendsQueryParams(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotQuery map[string][]string
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		gotQuery = r.URL.Query()
+	}, http.StatusOK)
+	defer srv.Close()
+
+	c.ListVideos(context.Background(), ListVideosOptions{
+		Page:         2,
+		ItemsPerPage: 50,
+		Search:       "cats",
+		Collection:   "col-1",
+		OrderBy:      OrderByTitle,
+	})
+
+	if gotMethod != http.MethodGet {
+		t.Errorf("expected GET, got %q", gotMethod)
+	}
+	if want := "/library/123/videos"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+
+	cases := []struct{ key, want string }{
+		{"page", "2"},
+		{"itemsPerPage", "50"},
+		{"search", "cats"},
+		{"collection", "col-1"},
+		{"orderBy", "title"},
+	}
+	for _, c := range cases {
+		if got := gotQuery[c.key]; len(got) != 1 || got[0] != c.want {
+			t.Errorf("%s = %v, want %q", c.key, got, c.want)
+		}
+	}
+}
+
+func TestListVideos_ZeroOptionsOmitQuery(t *testing.T) {
+	var gotQuery string
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotQuery = r.URL.RawQuery
+	}, http.StatusOK)
+	defer srv.Close()
+
+	c.ListVideos(context.Background(), ListVideosOptions{})
+
+	if gotQuery != "" {
+		t.Errorf("expected empty query string, got %q", gotQuery)
+	}
+}
+
+func TestListVideos_DecodesPage(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK,
+		`{"totalItems":3,"currentPage":1,"itemsPerPage":2,"items":[{"guid":"a"},{"guid":"b"}]}`)
+	defer srv.Close()
+
+	page, _, err := c.ListVideos(context.Background(), ListVideosOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if page.TotalItems != 3 || page.CurrentPage != 1 || page.ItemsPerPage != 2 {
+		t.Errorf("page metadata = %+v", page)
+	}
+	if len(page.Items) != 2 || page.Items[1].GUID != "b" {
+		t.Errorf("items = %+v", page.Items)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// AllVideos
+// -----------------------------------------------------------------------------
+
+func TestAllVideos_WalksEveryPage(t *testing.T) {
+	c, srv, calls := pagedVideoServer(t, 7)
+	defer srv.Close()
+
+	var got []string
+	for video, err := range c.AllVideos(context.Background(), ListVideosOptions{ItemsPerPage: 3}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, video.GUID)
+	}
+
+	if len(got) != 7 || got[0] != "video-0" || got[6] != "video-6" {
+		t.Errorf("videos = %v, want video-0..video-6", got)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("requests = %d, want 3", n)
+	}
+}
+
+func TestAllVideos_ExactMultipleDoesNotFetchEmptyPage(t *testing.T) {
+	c, srv, calls := pagedVideoServer(t, 6)
+	defer srv.Close()
+
+	count := 0
+	for _, err := range c.AllVideos(context.Background(), ListVideosOptions{ItemsPerPage: 3}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+	}
+
+	if count != 6 {
+		t.Errorf("videos = %d, want 6", count)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+}
+
+func TestAllVideos_BreakStopsFetching(t *testing.T) {
+	c, srv, calls := pagedVideoServer(t, 100)
+	defer srv.Close()
+
+	for video := range c.AllVideos(context.Background(), ListVideosOptions{ItemsPerPage: 10}) {
+		if video.GUID == "video-12" {
+			break
+		}
+	}
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+}
+
+func TestAllVideos_StartsAtRequestedPage(t *testing.T) {
+	c, srv, _ := pagedVideoServer(t, 10)
+	defer srv.Close()
+
+	var first string
+	for video := range c.AllVideos(context.Background(), ListVideosOptions{Page: 2, ItemsPerPage: 5}) {
+		first = video.GUID
+		break
+	}
+
+	if first != "video-5" {
+		t.Errorf("first video = %q, want video-5", first)
+	}
+}
+
+func TestAllVideos_ReuseStartsOver(t *testing.T) {
+	c, srv, _ := pagedVideoServer(t, 10)
+	defer srv.Close()
+
+	videos := c.AllVideos(context.Background(), ListVideosOptions{Page: 2, ItemsPerPage: 3})
+
+	for video := range videos {
+		if video.GUID == "video-6" {
+			break
+		}
+	}
+
+	var first string
+	for video := range videos {
+		first = video.GUID
+		break
+	}
+
+	if first != "video-3" {
+		t.Errorf("first video of second walk = %q, want video-3", first)
+	}
+}
+
+func TestAllVideos_ConcurrentRanges(t *testing.T) {
+	c, srv, _ := pagedVideoServer(t, 20)
+	defer srv.Close()
+
+	videos := c.AllVideos(context.Background(), ListVideosOptions{ItemsPerPage: 3})
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			var got []string
+			for video, err := range videos {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				got = append(got, video.GUID)
+			}
+			for i, guid := range got {
+				if want := fmt.Sprintf("video-%d", i); guid != want {
+					t.Errorf("videos[%d] = %q, want %q", i, guid, want)
+					return
+				}
+			}
+			if len(got) != 20 {
+				t.Errorf("videos = %d, want 20", len(got))
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestAllVideos_YieldsErrorAndStops(t *testing.T) {
+	c, srv := testServer(t, http.StatusUnauthorized, "")
+	defer srv.Close()
+
+	var errs []error
+	for _, err := range c.AllVideos(context.Background(), ListVideosOptions{}) {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], ErrUnauthorized) {
+		t.Errorf("errors = %v, want single ErrUnauthorized", errs)
+	}
+}
@@ -0,0 +1,81 @@
+package bunnystream
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// countingBody counts the bytes read from a request body. The transport may
+// read it from another goroutine, hence the atomic counter.
+type countingBody struct {
+	io.ReadCloser
+	n atomic.Int64
+}
+
+// Read implements io.Reader.
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n.Add(int64(n))
+	return n, err
+}
+
+// countBody wraps the body of req so the bytes sent can be read from the
+// returned counter. Requests without a body are left untouched.
+func countBody(req *http.Request) *countingBody {
+	counter := &countingBody{}
+	if req.Body != nil && req.Body != http.NoBody {
+		counter.ReadCloser = req.Body
+		req.Body = counter
+	}
+	return counter
+}
+
+// logAttempt emits one record for a request attempt. Successful attempts
+// are logged at Config.LogLevel and failed ones at Config.ErrorLogLevel.
+//
+// The AccessKey and TUS signature headers are never logged, and credentials
+// in the URL query are redacted.
+func (c *Client) logAttempt(ctx context.Context, req *http.Request, attempt int, sent int64, resp *Response, err error, elapsed time.Duration) {
+	level := c.config.LogLevel.Level()
+	msg := "bunnystream request"
+	if err != nil {
+		level = c.config.ErrorLogLevel.Level()
+		msg = "bunnystream request failed"
+	}
+
+	logger := c.config.Logger
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	path := *req.URL
+	path.Scheme, path.Host = "", ""
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", redactURL(&path)),
+		slog.Duration("duration", elapsed),
+		slog.Int("attempt", attempt+1),
+		slog.Int64("bytes_sent", sent),
+	}
+
+	if resp != nil {
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			slog.Int("bytes_received", len(resp.Body)),
+		)
+		if id := requestID(resp.Headers); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
@@ -0,0 +1,171 @@
+package bunnystream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// logRecorder collects the JSON log records written by a slog logger.
+type logRecorder struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (r *logRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buf.Write(p)
+}
+
+// records decodes every record logged so far.
+func (r *logRecorder) records(t *testing.T) []map[string]any {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []map[string]any
+	for line := range strings.Lines(r.buf.String()) {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+// loggedServer returns a client logging at debug level to the returned
+// recorder.
+func loggedServer(t *testing.T, handler http.HandlerFunc) (*Client, *logRecorder, func()) {
+	t.Helper()
+	c, srv := handlerServer(t, handler)
+	rec := &logRecorder{}
+	c.config.Logger = slog.New(slog.NewJSONHandler(rec, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return c, rec, srv.Close
+}
+
+// -----------------------------------------------------------------------------
+// Request logging
+// -----------------------------------------------------------------------------
+
+func TestLogging_RecordsRequestFields(t *testing.T) {
+	c, rec, done := loggedServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("CDN-RequestId", "req-1")
+		w.Write([]byte(`{"guid":"video-abc"}`))
+	})
+	defer done()
+
+	if _, _, err := c.CreateVideoObject(context.Background(), "My Video"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := rec.records(t)
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	r := records[0]
+
+	if r["level"] != "DEBUG" {
+		t.Errorf("level = %v, want DEBUG", r["level"])
+	}
+	if r["method"] != "POST" || r["path"] != "/library/123/videos" {
+		t.Errorf("method/path = %v %v", r["method"], r["path"])
+	}
+	if r["status"] != float64(200) || r["attempt"] != float64(1) {
+		t.Errorf("status/attempt = %v/%v", r["status"], r["attempt"])
+	}
+	if r["bytes_received"] != float64(len(`{"guid":"video-abc"}`)) {
+		t.Errorf("bytes_received = %v", r["bytes_received"])
+	}
+	if sent, _ := r["bytes_sent"].(float64); sent == 0 {
+		t.Error("bytes_sent should count the JSON body")
+	}
+	if r["request_id"] != "req-1" {
+		t.Errorf("request_id = %v, want req-1", r["request_id"])
+	}
+	if _, ok := r["duration"]; !ok {
+		t.Error("missing duration")
+	}
+}
+
+func TestLogging_RetriesAndFailures(t *testing.T) {
+	calls := 0
+	c, rec, done := loggedServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	defer done()
+
+	if _, _, err := c.GetVideo(context.Background(), "video-abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := rec.records(t)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if records[0]["level"] != "WARN" || records[0]["error"] == nil {
+		t.Errorf("first attempt = %v, want a WARN record with an error", records[0])
+	}
+	if records[1]["attempt"] != float64(2) {
+		t.Errorf("second attempt = %v, want 2", records[1]["attempt"])
+	}
+}
+
+func TestLogging_ConfigurableLevels(t *testing.T) {
+	c, rec, done := loggedServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	defer done()
+	c.config.LogLevel = slog.LevelInfo
+
+	c.GetVideo(context.Background(), "video-abc")
+
+	if records := rec.records(t); len(records) != 1 || records[0]["level"] != "INFO" {
+		t.Errorf("records = %v, want one INFO record", records)
+	}
+}
+
+func TestLogging_DisabledLevelIsSkipped(t *testing.T) {
+	c, rec, done := loggedServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	defer done()
+	c.config.Logger = slog.New(slog.NewJSONHandler(rec, nil)) // Info and above
+
+	c.GetVideo(context.Background(), "video-abc")
+
+	if records := rec.records(t); len(records) != 0 {
+		t.Errorf("records = %v, want none below the handler level", records)
+	}
+}
+
+func TestLogging_RedactsCredentials(t *testing.T) {
+	c, rec, done := loggedServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer done()
+
+	req, err := c.request(context.Background(), http.MethodGet, c.buildURL("/play?token=s3cret"), nil, "")
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	c.doRequest(req)
+
+	logged := rec.buf.String()
+	for _, secret := range []string{"test-key", "s3cret"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log leaks %q: %s", secret, logged)
+		}
+	}
+}
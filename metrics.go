@@ -0,0 +1,184 @@
+package bunnystream
+
+import (
+	"expvar"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics receives measurements about the requests made by a Client, e.g.
+// to export them to Prometheus. Endpoints are normalized templates such as
+// "/library/{id}/videos/{guid}", so they are safe to use as metric labels.
+//
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest is called after every request attempt. status is zero
+	// when no response was received.
+	ObserveRequest(endpoint, method string, status int, duration time.Duration)
+
+	// ObserveUploadBytes is called with the number of bytes sent by every
+	// upload attempt.
+	ObserveUploadBytes(endpoint string, n int64)
+
+	// ObserveRetry is called every time a request is about to be retried.
+	ObserveRetry(endpoint, method string)
+}
+
+// NopMetrics is a Metrics discarding every measurement. It is the default.
+type NopMetrics struct{}
+
+// ObserveRequest implements Metrics.
+func (NopMetrics) ObserveRequest(string, string, int, time.Duration) {}
+
+// ObserveUploadBytes implements Metrics.
+func (NopMetrics) ObserveUploadBytes(string, int64) {}
+
+// ObserveRetry implements Metrics.
+func (NopMetrics) ObserveRetry(string, string) {}
+
+// ExpvarMetrics is a Metrics publishing its counters with the expvar
+// package, under /debug/vars when the expvar handler is served. The
+// published map holds:
+//
+//   - "requests": request count by "METHOD endpoint status"
+//   - "request_seconds": total request duration by "METHOD endpoint"
+//   - "upload_bytes": bytes uploaded by endpoint
+//   - "retries": retry count by "METHOD endpoint"
+type ExpvarMetrics struct {
+	requests    *expvar.Map
+	seconds     *expvar.Map
+	uploadBytes *expvar.Map
+	retries     *expvar.Map
+}
+
+// expvarMu serializes NewExpvarMetrics, so concurrent calls with the same
+// name share one published map instead of racing to create it.
+var expvarMu sync.Mutex
+
+// NewExpvarMetrics returns an ExpvarMetrics published under name. Calling it
+// again with the same name shares the counters. It returns an error if name
+// is already published by something other than an ExpvarMetrics.
+func NewExpvarMetrics(name string) (*ExpvarMetrics, error) {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	var root *expvar.Map
+	switch v := expvar.Get(name).(type) {
+	case nil:
+		root = expvar.NewMap(name)
+	case *expvar.Map:
+		root = v
+	default:
+		return nil, fmt.Errorf("expvar %q is already published as %T", name, v)
+	}
+
+	// Check every child before creating any, so a conflict does not leave
+	// a half-initialized map published.
+	for _, key := range []string{"requests", "request_seconds", "upload_bytes", "retries"} {
+		if v := root.Get(key); v != nil {
+			if _, ok := v.(*expvar.Map); !ok {
+				return nil, fmt.Errorf("expvar %q: %q is already set as %T", name, key, v)
+			}
+		}
+	}
+
+	return &ExpvarMetrics{
+		requests:    childMap(root, "requests"),
+		seconds:     childMap(root, "request_seconds"),
+		uploadBytes: childMap(root, "upload_bytes"),
+		retries:     childMap(root, "retries"),
+	}, nil
+}
+
+// childMap returns the map stored under key in root, creating it if needed.
+// Callers hold expvarMu and have checked that key holds no other kind of
+// variable.
+func childMap(root *expvar.Map, key string) *expvar.Map {
+	if m, ok := root.Get(key).(*expvar.Map); ok {
+		return m
+	}
+	m := new(expvar.Map).Init()
+	root.Set(key, m)
+	return m
+}
+
+// ObserveRequest implements Metrics.
+func (m *ExpvarMetrics) ObserveRequest(endpoint, method string, status int, duration time.Duration) {
+	m.requests.Add(method+" "+endpoint+" "+strconv.Itoa(status), 1)
+	m.seconds.AddFloat(method+" "+endpoint, duration.Seconds())
+}
+
+// ObserveUploadBytes implements Metrics.
+func (m *ExpvarMetrics) ObserveUploadBytes(endpoint string, n int64) {
+	m.uploadBytes.Add(endpoint, n)
+}
+
+// ObserveRetry implements Metrics.
+func (m *ExpvarMetrics) ObserveRetry(endpoint, method string) {
+	m.retries.Add(method+" "+endpoint, 1)
+}
+
+// staticSegments lists the path segments of API endpoints that are not
+// parameters.
+var staticSegments = map[string]bool{
+	"library":     true,
+	"videos":      true,
+	"collections": true,
+	"fetch":       true,
+	"tusupload":   true,
+	"captions":    true,
+	"thumbnail":   true,
+	"reencode":    true,
+	"repackage":   true,
+	"resolutions": true,
+	"heatmap":     true,
+	"statistics":  true,
+	"play":        true,
+	"transcribe":  true,
+}
+
+// paramNames names the parameter following a static segment.
+var paramNames = map[string]string{
+	"library":     "{id}",
+	"videos":      "{guid}",
+	"collections": "{guid}",
+	"tusupload":   "{upload}",
+	"captions":    "{lang}",
+}
+
+// endpointTemplate replaces the IDs in an API path with placeholders, e.g.
+// /library/123/videos/abc becomes /library/{id}/videos/{guid}.
+func endpointTemplate(path string) string {
+	segments := strings.Split(path, "/")
+
+	prev := ""
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if !staticSegments[segment] {
+			name, ok := paramNames[prev]
+			if !ok {
+				name = "{id}"
+			}
+			segments[i] = name
+		}
+		prev = segment
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// endpoint returns the endpoint template of u, relative to the client's
+// base URL.
+func (c *Client) endpoint(u *url.URL) string {
+	path := u.Path
+	if base, err := url.Parse(c.baseURL); err == nil && u.Host == base.Host {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	return endpointTemplate(path)
+}
@@ -0,0 +1,260 @@
+package bunnystream
+
+import (
+	"bytes"
+	"context"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// metricsRecorder is a Metrics remembering every observation.
+type metricsRecorder struct {
+	mu       sync.Mutex
+	requests []string
+	uploaded map[string]int64
+	retries  []string
+}
+
+func (m *metricsRecorder) ObserveRequest(endpoint, method string, status int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, fmt.Sprintf("%s %s %d", method, endpoint, status))
+}
+
+func (m *metricsRecorder) ObserveUploadBytes(endpoint string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.uploaded == nil {
+		m.uploaded = make(map[string]int64)
+	}
+	m.uploaded[endpoint] += n
+}
+
+func (m *metricsRecorder) ObserveRetry(endpoint, method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries = append(m.retries, method+" "+endpoint)
+}
+
+// -----------------------------------------------------------------------------
+// endpointTemplate
+// -----------------------------------------------------------------------------
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/library/123/videos":                 "/library/{id}/videos",
+		"/library/123/videos/abc-def":         "/library/{id}/videos/{guid}",
+		"/library/123/videos/fetch":           "/library/{id}/videos/fetch",
+		"/library/123/collections/abc":        "/library/{id}/collections/{guid}",
+		"/tusupload":                          "/tusupload",
+		"/tusupload/5f1c":                     "/tusupload/{upload}",
+		"/library/123/videos/abc/captions/en": "/library/{id}/videos/{guid}/captions/{lang}",
+		"/library/123/unknown/42":             "/library/{id}/{id}/{id}",
+	}
+	for path, want := range tests {
+		if got := endpointTemplate(path); got != want {
+			t.Errorf("endpointTemplate(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestEndpoint_StripsBasePath(t *testing.T) {
+	cfg := baseConfig()
+	cfg.BaseURL = "https://proxy.example.com/bunny"
+	c := mustNewClient(t, cfg)
+
+	u, _ := url.Parse("https://proxy.example.com/bunny/library/123/videos/abc")
+	if got := c.endpoint(u); got != "/library/{id}/videos/{guid}" {
+		t.Errorf("endpoint = %q, want /library/{id}/videos/{guid}", got)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Client integration
+// -----------------------------------------------------------------------------
+
+func TestMetrics_DefaultsToNop(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+	if _, ok := c.config.Metrics.(NopMetrics); !ok {
+		t.Errorf("Metrics = %T, want NopMetrics", c.config.Metrics)
+	}
+}
+
+func TestMetrics_ObservesRequestsAndRetries(t *testing.T) {
+	calls := 0
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	defer srv.Close()
+	m := &metricsRecorder{}
+	c.config.Metrics = m
+
+	if _, _, err := c.GetVideo(context.Background(), "video-abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "GET /library/{id}/videos/{guid} 429,GET /library/{id}/videos/{guid} 200"
+	if got := strings.Join(m.requests, ","); got != want {
+		t.Errorf("requests = %s, want %s", got, want)
+	}
+	if len(m.retries) != 1 || m.retries[0] != "GET /library/{id}/videos/{guid}" {
+		t.Errorf("retries = %v, want one for the video endpoint", m.retries)
+	}
+	if len(m.uploaded) != 0 {
+		t.Errorf("uploaded = %v, want nothing for a GET", m.uploaded)
+	}
+}
+
+func TestMetrics_ObservesUploadBytes(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, `{}`)
+	defer srv.Close()
+	m := &metricsRecorder{}
+	c.config.Metrics = m
+
+	if _, err := c.UploadVideo(context.Background(), "video-abc", bytes.NewReader(payload(1234))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := m.uploaded["/library/{id}/videos/{guid}"]; got != 1234 {
+		t.Errorf("uploaded bytes = %d, want 1234", got)
+	}
+}
+
+func TestMetrics_ObservesTUSChunkRetries(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+	m := &metricsRecorder{}
+	c.config.Metrics = m
+
+	standIn.failPatch = func(n int) (int, int) {
+		if n == 1 {
+			return 10, http.StatusServiceUnavailable
+		}
+		return 0, 0
+	}
+
+	if _, err := c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(payload(50)), 50, ChunkSize(30)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(m.retries) != 1 || !strings.HasPrefix(m.retries[0], "PATCH /tusupload/") {
+		t.Errorf("retries = %v, want one PATCH retry", m.retries)
+	}
+	var total int64
+	for endpoint, n := range m.uploaded {
+		if !strings.HasPrefix(endpoint, "/tusupload") {
+			t.Errorf("unexpected upload endpoint %q", endpoint)
+		}
+		total += n
+	}
+	if total < 50 {
+		t.Errorf("uploaded bytes = %d, want at least 50", total)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// ExpvarMetrics
+// -----------------------------------------------------------------------------
+
+// expvarNames numbers the names handed out by expvarName.
+var expvarNames atomic.Int32
+
+// expvarName returns an expvar name not published yet. expvar names live for
+// the whole process, so every run of a test, e.g. with -count, needs its own.
+func expvarName(t *testing.T) string {
+	for {
+		name := fmt.Sprintf("bunnystream_%s_%d", t.Name(), expvarNames.Add(1))
+		if expvar.Get(name) == nil {
+			return name
+		}
+	}
+}
+
+func TestExpvarMetrics(t *testing.T) {
+	name := expvarName(t)
+	m, err := NewExpvarMetrics(name)
+	if err != nil {
+		t.Fatalf("NewExpvarMetrics: %v", err)
+	}
+	m.ObserveRequest("/library/{id}/videos", "POST", 200, 1500*time.Millisecond)
+	m.ObserveRequest("/library/{id}/videos", "POST", 200, 500*time.Millisecond)
+	m.ObserveUploadBytes("/library/{id}/videos/{guid}", 2048)
+	m.ObserveRetry("/library/{id}/videos", "POST")
+
+	// A second instance shares the published counters.
+	again, err := NewExpvarMetrics(name)
+	if err != nil {
+		t.Fatalf("NewExpvarMetrics again: %v", err)
+	}
+	again.ObserveRetry("/library/{id}/videos", "POST")
+
+	root := expvar.Get(name).(*expvar.Map)
+	get := func(group, key string) string {
+		return root.Get(group).(*expvar.Map).Get(key).String()
+	}
+
+	if got := get("requests", "POST /library/{id}/videos 200"); got != "2" {
+		t.Errorf("requests = %s, want 2", got)
+	}
+	if got := get("request_seconds", "POST /library/{id}/videos"); got != "2" {
+		t.Errorf("request_seconds = %s, want 2", got)
+	}
+	if got := get("upload_bytes", "/library/{id}/videos/{guid}"); got != "2048" {
+		t.Errorf("upload_bytes = %s, want 2048", got)
+	}
+	if got := get("retries", "POST /library/{id}/videos"); got != "2" {
+		t.Errorf("retries = %s, want 2", got)
+	}
+}
+
+func TestExpvarMetrics_NameTaken(t *testing.T) {
+	taken := expvarName(t)
+	expvar.NewInt(taken)
+	if _, err := NewExpvarMetrics(taken); err == nil {
+		t.Error("expected an error for a name published as an Int")
+	}
+
+	name := expvarName(t)
+	root := expvar.NewMap(name)
+	root.Set("retries", new(expvar.Int))
+	if _, err := NewExpvarMetrics(name); err == nil {
+		t.Error("expected an error for a child published as an Int")
+	}
+	if v := root.Get("requests"); v != nil {
+		t.Errorf("requests = %v, want no child created after the conflict", v)
+	}
+}
+
+func TestExpvarMetrics_ConcurrentCreation(t *testing.T) {
+	name := expvarName(t)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			m, err := NewExpvarMetrics(name)
+			if err != nil {
+				t.Errorf("NewExpvarMetrics: %v", err)
+				return
+			}
+			m.ObserveRetry("/library/{id}/videos", "POST")
+		})
+	}
+	wg.Wait()
+
+	retries := expvar.Get(name).(*expvar.Map).Get("retries").(*expvar.Map)
+	if got := retries.Get("POST /library/{id}/videos").String(); got != "10" {
+		t.Errorf("retries = %s, want 10", got)
+	}
+}
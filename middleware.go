@@ -0,0 +1,170 @@
+package bunnystream
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Doer sends an HTTP request and returns its response. *http.Client
+// implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts an ordinary function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to add behavior around every request sent to the
+// API, such as tracing headers or audit logging. It runs once per attempt,
+// so retried requests pass through it again.
+type Middleware func(next Doer) Doer
+
+// chainMiddleware wraps doer with middlewares, the first one being the
+// outermost.
+func chainMiddleware(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			doer = middlewares[i](doer)
+		}
+	}
+	return doer
+}
+
+// sensitiveHeaders lists the request headers carrying credentials.
+var sensitiveHeaders = []string{"AccessKey", "AuthorizationSignature", "Authorization", "Cookie"}
+
+// redactHeader returns a copy of h with credentials replaced by "REDACTED".
+func redactHeader(h http.Header) http.Header {
+	redacted := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "REDACTED")
+		}
+	}
+	return redacted
+}
+
+// HeaderMiddleware sets the given headers on every request, e.g. tracing
+// or proxy authentication headers. Headers set by the client itself, such
+// as AccessKey, are overwritten.
+func HeaderMiddleware(headers http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for name, values := range headers {
+				req.Header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// TimingMiddleware calls observe after every request with its duration. The
+// status is zero when no response was received.
+func TimingMiddleware(observe func(req *http.Request, status int, d time.Duration)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			observe(req, status, time.Since(start))
+
+			return resp, err
+		})
+	}
+}
+
+// LoggingMiddleware logs every request at level with its method, redacted
+// URL, status and duration. Transport errors are logged at slog.LevelError.
+//
+// Config.Logger already logs each attempt with more detail; use this to log
+// at a different point of the middleware chain.
+func LoggingMiddleware(logger *slog.Logger, level slog.Level) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", redactURL(req.URL)),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(req.Context(), slog.LevelError, "bunnystream http request failed", attrs...)
+				return resp, err
+			}
+
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			logger.LogAttrs(req.Context(), level, "bunnystream http request", attrs...)
+
+			return resp, err
+		})
+	}
+}
+
+// DumpMiddleware writes every request and response to w in HTTP/1.1 wire
+// format, for debugging. Credentials are redacted. Bodies are only included
+// when body is true; avoid it for uploads, since the whole body is then
+// buffered in memory.
+func DumpMiddleware(w io.Writer, body bool) Middleware {
+	var mu sync.Mutex
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			// Dump a copy with redacted headers. With body, dumping drains
+			// the shared body and leaves a buffered replacement on the copy.
+			dump := req.Clone(req.Context())
+			dump.Header = redactHeader(req.Header)
+			if redacted, err := url.Parse(redactURL(req.URL)); err == nil {
+				dump.URL = redacted
+			}
+
+			reqDump, err := httputil.DumpRequestOut(dump, body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to dump request: %w", err)
+			}
+			if body {
+				req = req.Clone(req.Context())
+				req.Body = dump.Body
+			}
+
+			resp, err := next.Do(req)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			w.Write(reqDump)
+			if err != nil {
+				fmt.Fprintf(w, "\n\n--> error: %v\n\n", err)
+				return resp, err
+			}
+
+			respDump, dumpErr := httputil.DumpResponse(resp, body)
+			if dumpErr != nil {
+				fmt.Fprintf(w, "\n\n--> failed to dump response: %v\n\n", dumpErr)
+				return resp, err
+			}
+			w.Write(respDump)
+			io.WriteString(w, "\n\n")
+
+			return resp, err
+		})
+	}
+}
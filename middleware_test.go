@@ -0,0 +1,183 @@
+package bunnystream
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// middlewareServer returns a client sending through middlewares to handler.
+func middlewareServer(t *testing.T, handler http.HandlerFunc, middlewares ...Middleware) (*Client, *httptest.Server) {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	client, err := NewClient(&Config{
+		APIKey:       "test-key",
+		LibraryID:    "123",
+		BaseURL:      srv.URL,
+		HTTPClient:   srv.Client(),
+		Middleware:   middlewares,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+	if err != nil {
+		srv.Close()
+		t.Fatalf("failed to create test client: %v", err)
+	}
+
+	return client, srv
+}
+
+// tagMiddleware appends name to calls before and after the next Doer runs.
+func tagMiddleware(name string, calls *[]string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, name+">")
+			resp, err := next.Do(req)
+			*calls = append(*calls, "<"+name)
+			return resp, err
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Middleware chain
+// -----------------------------------------------------------------------------
+
+func TestMiddleware_RunsInOrder(t *testing.T) {
+	var calls []string
+	c, srv := middlewareServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "server")
+		w.Write([]byte(`{}`))
+	}, tagMiddleware("a", &calls), nil, tagMiddleware("b", &calls))
+	defer srv.Close()
+
+	if _, _, err := c.GetVideo(context.Background(), "video-abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "a>,b>,server,<b,<a"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+}
+
+func TestMiddleware_RunsForEveryAttempt(t *testing.T) {
+	attempts := 0
+	var calls []string
+	c, srv := middlewareServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}, tagMiddleware("a", &calls))
+	defer srv.Close()
+
+	c.GetVideo(context.Background(), "video-abc")
+
+	if len(calls) != 4 {
+		t.Errorf("calls = %v, want the middleware to run twice", calls)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Ready-made middlewares
+// -----------------------------------------------------------------------------
+
+func TestHeaderMiddleware(t *testing.T) {
+	var got http.Header
+	c, srv := middlewareServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{}`))
+	}, HeaderMiddleware(http.Header{"traceparent": {"00-abc-01"}}))
+	defer srv.Close()
+
+	c.GetVideo(context.Background(), "video-abc")
+
+	if got.Get("Traceparent") != "00-abc-01" {
+		t.Errorf("Traceparent = %q, want %q", got.Get("Traceparent"), "00-abc-01")
+	}
+	if got.Get("AccessKey") != "test-key" {
+		t.Error("client headers should be kept")
+	}
+}
+
+func TestTimingMiddleware(t *testing.T) {
+	var (
+		gotPath   string
+		gotStatus int
+		gotDur    time.Duration
+	)
+	c, srv := middlewareServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}, TimingMiddleware(func(req *http.Request, status int, d time.Duration) {
+		gotPath, gotStatus, gotDur = req.URL.Path, status, d
+	}))
+	defer srv.Close()
+
+	c.CreateVideoObject(context.Background(), "My Video")
+
+	if gotPath != "/library/123/videos" || gotStatus != http.StatusCreated || gotDur <= 0 {
+		t.Errorf("observed %q %d %v", gotPath, gotStatus, gotDur)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	c, srv := middlewareServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}, LoggingMiddleware(logger, slog.LevelInfo))
+	defer srv.Close()
+
+	c.GetVideo(context.Background(), "video-abc")
+
+	logged := buf.String()
+	for _, want := range []string{"method=GET", "/library/123/videos/video-abc", "status=200", "duration="} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("log %q missing %q", logged, want)
+		}
+	}
+}
+
+func TestDumpMiddleware_RedactsAndKeepsBody(t *testing.T) {
+	var buf bytes.Buffer
+	var gotBody, gotKey string
+	c, srv := middlewareServer(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody, gotKey = string(b), r.Header.Get("AccessKey")
+		w.Write([]byte(`{"guid":"video-abc"}`))
+	}, DumpMiddleware(&buf, true))
+	defer srv.Close()
+
+	if _, _, err := c.CreateVideoObject(context.Background(), "My Video"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(gotBody, "My Video") {
+		t.Errorf("server body = %q, dumping should not consume it", gotBody)
+	}
+	if gotKey != "test-key" {
+		t.Errorf("server AccessKey = %q, dumping should not redact the sent request", gotKey)
+	}
+
+	dump := buf.String()
+	if strings.Contains(dump, "test-key") {
+		t.Errorf("dump leaks the API key:\n%s", dump)
+	}
+	for _, want := range []string{"POST /library/123/videos", "Accesskey: REDACTED", "My Video", `{"guid":"video-abc"}`} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("dump missing %q:\n%s", want, dump)
+		}
+	}
+}
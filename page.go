@@ -0,0 +1,63 @@
+package bunnystream
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultItemsPerPage is the page size used by the iterators when none is
+// set in the list options.
+const DefaultItemsPerPage = 100
+
+// Page is a single page of results returned by a list endpoint.
+type Page[T any] struct {
+	// TotalItems is the total number of items across all pages.
+	TotalItems int `json:"totalItems"`
+
+	// CurrentPage is the 1-based index of this page.
+	CurrentPage int `json:"currentPage"`
+
+	// ItemsPerPage is the page size used by the API.
+	ItemsPerPage int `json:"itemsPerPage"`
+
+	// Items holds the results of this page.
+	Items []T `json:"items"`
+}
+
+// hasNext reports whether more pages follow this one.
+func (p *Page[T]) hasNext() bool {
+	if len(p.Items) == 0 || p.ItemsPerPage < 1 {
+		return false
+	}
+	return p.CurrentPage*p.ItemsPerPage < p.TotalItems
+}
+
+// paginate walks a list endpoint lazily, fetching the next page only once
+// every item of the current one has been yielded. Iteration stops at the
+// first error, which is yielded with the zero value of T. Every iteration
+// of the returned sequence starts over at start.
+func paginate[T any](ctx context.Context, start int, fetch func(ctx context.Context, page int) (*Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		page := max(start, 1)
+
+		for {
+			result, err := fetch(ctx, page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range result.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !result.hasNext() {
+				return
+			}
+			page++
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package bunnystream
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUploadSignatureInvalid is returned by VerifyUploadSignature when the
+	// signature does not match the video, library and expiration time.
+	ErrUploadSignatureInvalid = errors.New("upload signature is invalid")
+
+	// ErrUploadSignatureExpired is returned by VerifyUploadSignature when the
+	// expiration time of the signature has passed.
+	ErrUploadSignatureExpired = errors.New("upload signature has expired")
+)
+
+// PresignedUpload holds everything a browser or mobile app needs to upload a
+// video directly to Bunny with a TUS client such as tus-js-client, without
+// ever seeing the API key.
+//
+// It is JSON-serializable so it can be returned as-is from your API.
+type PresignedUpload struct {
+	// VideoID is the ID of the video object the upload is bound to.
+	VideoID string `json:"videoId"`
+
+	// LibraryID is the ID of the video library.
+	LibraryID string `json:"libraryId"`
+
+	// Expire is the unix timestamp after which the signature is rejected.
+	Expire int64 `json:"expire"`
+
+	// Signature is hex(SHA256(libraryID + apiKey + expire + videoID)).
+	Signature string `json:"signature"`
+
+	// Endpoint is the TUS endpoint to upload to.
+	Endpoint string `json:"endpoint"`
+}
+
+// Headers returns the authorization headers the TUS client must send with
+// every request.
+//
+//	new tus.Upload(file, { endpoint: p.endpoint, headers: p.headers, ... })
+func (p *PresignedUpload) Headers() map[string]string {
+	return map[string]string{
+		"AuthorizationSignature": p.Signature,
+		"AuthorizationExpire":    strconv.FormatInt(p.Expire, 10),
+		"VideoId":                p.VideoID,
+		"LibraryId":              p.LibraryID,
+	}
+}
+
+// PresignUpload creates a video object and returns credentials allowing a
+// client to upload its file directly to Bunny over TUS until ttl elapses.
+//
+// The signature only authorizes uploading to this single video. A ttl of
+// zero or less defaults to DefaultUploadExpiry.
+//
+// SECURITY: Call this server-side only, after authenticating the user; the
+// API key itself never leaves the server.
+func (c *Client) PresignUpload(ctx context.Context, title string, ttl time.Duration, opts ...VideoOption) (*PresignedUpload, error) {
+	if ttl < 1 {
+		ttl = DefaultUploadExpiry
+	}
+
+	video, _, err := c.CreateVideoObject(ctx, title, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(video.GUID) == "" {
+		return nil, ErrVideoIDRequired
+	}
+
+	expire := time.Now().Add(ttl).Unix()
+
+	return &PresignedUpload{
+		VideoID:   video.GUID,
+		LibraryID: c.libraryID,
+		Expire:    expire,
+		Signature: tusSignature(c.libraryID, c.apiKey, expire, video.GUID),
+		Endpoint:  c.buildURL("/tusupload"),
+	}, nil
+}
+
+// VerifyUploadSignature checks that signature was issued by this client's
+// API key for videoID and expire, and that it has not expired yet.
+//
+// Useful in tests, or to validate credentials echoed back by a client.
+func (c *Client) VerifyUploadSignature(videoID string, expire int64, signature string) error {
+	want := tusSignature(c.libraryID, c.apiKey, expire, videoID)
+	if subtle.ConstantTimeCompare([]byte(want), []byte(strings.ToLower(signature))) != 1 {
+		return ErrUploadSignatureInvalid
+	}
+
+	if time.Now().Unix() > expire {
+		return ErrUploadSignatureExpired
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package bunnystream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPresignUpload_CreatesVideoAndSigns(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, `{"guid":"video-abc","title":"My Video"}`)
+	defer srv.Close()
+
+	before := time.Now()
+	p, err := c.PresignUpload(context.Background(), "My Video", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.VideoID != "video-abc" || p.LibraryID != "123" {
+		t.Errorf("VideoID/LibraryID = %q/%q", p.VideoID, p.LibraryID)
+	}
+	if want := srv.URL + "/tusupload"; p.Endpoint != want {
+		t.Errorf("Endpoint = %q, want %q", p.Endpoint, want)
+	}
+	if p.Expire < before.Add(time.Hour).Unix() || p.Expire > time.Now().Add(time.Hour).Unix() {
+		t.Errorf("Expire = %d, want about one hour from now", p.Expire)
+	}
+	if want := tusSignature("123", "test-key", p.Expire, "video-abc"); p.Signature != want {
+		t.Errorf("Signature = %q, want %q", p.Signature, want)
+	}
+	if err := c.VerifyUploadSignature(p.VideoID, p.Expire, p.Signature); err != nil {
+		t.Errorf("VerifyUploadSignature rejected a fresh signature: %v", err)
+	}
+}
+
+func TestPresignUpload_DefaultTTL(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, `{"guid":"video-abc"}`)
+	defer srv.Close()
+
+	p, err := c.PresignUpload(context.Background(), "My Video", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if earliest := time.Now().Add(DefaultUploadExpiry - time.Minute).Unix(); p.Expire < earliest {
+		t.Errorf("Expire = %d, want about DefaultUploadExpiry from now", p.Expire)
+	}
+}
+
+func TestPresignUpload_PropagatesCreateErrors(t *testing.T) {
+	c, srv := testServer(t, http.StatusUnauthorized, "")
+	defer srv.Close()
+
+	if _, err := c.PresignUpload(context.Background(), "My Video", time.Hour); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+	if _, err := c.PresignUpload(context.Background(), "", time.Hour); !errors.Is(err, ErrTitleRequired) {
+		t.Errorf("expected ErrTitleRequired, got %v", err)
+	}
+}
+
+func TestPresignUpload_MissingGUID(t *testing.T) {
+	c, srv := testServer(t, http.StatusOK, `{}`)
+	defer srv.Close()
+
+	if _, err := c.PresignUpload(context.Background(), "My Video", time.Hour); !errors.Is(err, ErrVideoIDRequired) {
+		t.Errorf("expected ErrVideoIDRequired, got %v", err)
+	}
+}
+
+func TestPresignedUpload_HeadersAndJSON(t *testing.T) {
+	p := &PresignedUpload{
+		VideoID:   "video-abc",
+		LibraryID: "123",
+		Expire:    1700000000,
+		Signature: "sig",
+		Endpoint:  "https://video.bunnycdn.com/tusupload",
+	}
+
+	h := p.Headers()
+	if h["AuthorizationSignature"] != "sig" || h["AuthorizationExpire"] != "1700000000" ||
+		h["VideoId"] != "video-abc" || h["LibraryId"] != "123" {
+		t.Errorf("Headers = %v", h)
+	}
+
+	b, _ := json.Marshal(p)
+	want := `{"videoId":"video-abc","libraryId":"123","expire":1700000000,"signature":"sig","endpoint":"https://video.bunnycdn.com/tusupload"}`
+	if string(b) != want {
+		t.Errorf("JSON = %s, want %s", b, want)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// VerifyUploadSignature
+// -----------------------------------------------------------------------------
+
+func TestVerifyUploadSignature(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+	future := time.Now().Add(time.Hour).Unix()
+	past := time.Now().Add(-time.Hour).Unix()
+
+	tests := []struct {
+		name      string
+		videoID   string
+		expire    int64
+		signature string
+		wantErr   error
+	}{
+		{"valid", "video-abc", future, tusSignature("123", "test-key", future, "video-abc"), nil},
+		{"other video", "video-xyz", future, tusSignature("123", "test-key", future, "video-abc"), ErrUploadSignatureInvalid},
+		{"tampered expire", "video-abc", future + 1, tusSignature("123", "test-key", future, "video-abc"), ErrUploadSignatureInvalid},
+		{"other key", "video-abc", future, tusSignature("123", "other-key", future, "video-abc"), ErrUploadSignatureInvalid},
+		{"expired", "video-abc", past, tusSignature("123", "test-key", past, "video-abc"), ErrUploadSignatureExpired},
+		{"garbage", "video-abc", future, "42", ErrUploadSignatureInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.VerifyUploadSignature(tt.videoID, tt.expire, tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyUploadSignature = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
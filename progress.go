@@ -0,0 +1,192 @@
+package bunnystream
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// progressInterval is the minimum time between two progress reports, so
+// callbacks are not flooded on fast links. The final report is always sent.
+const progressInterval = 200 * time.Millisecond
+
+// UploadProgress describes the state of an upload in progress.
+type UploadProgress struct {
+	// BytesSent is the number of bytes accepted so far, including bytes
+	// stored by a previous session when resuming a TUS upload.
+	BytesSent int64
+
+	// TotalBytes is the size of the upload, or -1 when it is unknown.
+	TotalBytes int64
+
+	// BytesPerSecond is the average throughput since the upload started.
+	BytesPerSecond float64
+
+	// ETA is the estimated time left, or 0 when it cannot be estimated.
+	ETA time.Duration
+}
+
+// Percent returns the completion percentage from 0 to 100, or -1 when the
+// total size is unknown.
+func (p UploadProgress) Percent() float64 {
+	if p.TotalBytes <= 0 {
+		return -1
+	}
+	return float64(p.BytesSent) / float64(p.TotalBytes) * 100
+}
+
+// WithProgress registers a callback that reports upload progress. It works
+// with both UploadVideo and UploadVideoResumable, and is called from the
+// goroutine sending the request, at most every 200ms plus once when the body
+// has been fully read. When a request is retried, BytesSent drops back to the
+// number of bytes the new attempt starts from.
+func WithProgress(fn func(UploadProgress)) UploadVideoOption {
+	return func(o *UploadVideoOptions) {
+		o.onProgress = fn
+	}
+}
+
+// progressTracker accumulates the bytes read from an upload body and reports
+// them to a callback.
+type progressTracker struct {
+	mu sync.Mutex
+
+	fn    func(UploadProgress)
+	total int64
+	start time.Time
+
+	// base is the offset the current session started from; only bytes past
+	// it count towards throughput.
+	base       int64
+	sent       int64
+	lastReport time.Time
+}
+
+// newProgressTracker returns a tracker for an upload of total bytes (-1 if
+// unknown), or nil when fn is nil.
+func newProgressTracker(fn func(UploadProgress), total int64) *progressTracker {
+	if fn == nil {
+		return nil
+	}
+	return &progressTracker{fn: fn, total: total, start: time.Now()}
+}
+
+// set moves the tracker to an absolute offset, e.g. after a retry.
+func (t *progressTracker) set(offset int64) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.sent == 0 && t.base == 0 {
+		t.base = offset
+	}
+	t.sent = offset
+}
+
+// add records n more bytes sent and reports progress if due. The report is
+// forced when eof is set or the total size is reached.
+func (t *progressTracker) add(n int, eof bool) {
+	if t == nil || (n <= 0 && !eof) {
+		return
+	}
+
+	t.mu.Lock()
+	t.sent += int64(n)
+	now := time.Now()
+	done := eof || (t.total > 0 && t.sent >= t.total)
+	if !done && now.Sub(t.lastReport) < progressInterval {
+		t.mu.Unlock()
+		return
+	}
+	t.lastReport = now
+	p := t.snapshot(now)
+	t.mu.Unlock()
+
+	t.fn(p)
+}
+
+// snapshot builds the current UploadProgress. Callers must hold t.mu.
+func (t *progressTracker) snapshot(now time.Time) UploadProgress {
+	p := UploadProgress{BytesSent: t.sent, TotalBytes: t.total}
+
+	if elapsed := now.Sub(t.start).Seconds(); elapsed > 0 {
+		p.BytesPerSecond = float64(max(t.sent-t.base, 0)) / elapsed
+	}
+
+	if t.total > 0 && p.BytesPerSecond > 0 {
+		left := float64(max(t.total-t.sent, 0))
+		p.ETA = time.Duration(left / p.BytesPerSecond * float64(time.Second))
+	}
+
+	return p
+}
+
+// wrap returns body reporting every byte read to the tracker.
+func (t *progressTracker) wrap(body io.ReadCloser) io.ReadCloser {
+	if t == nil || body == nil || body == http.NoBody {
+		return body
+	}
+	return &progressReader{ReadCloser: body, tracker: t}
+}
+
+// trackRequest wraps the body of req, and any body recreated for a retry,
+// so that reading it reports progress from offset 0.
+func (t *progressTracker) trackRequest(req *http.Request) {
+	if t == nil {
+		return
+	}
+
+	req.Body = t.wrap(req.Body)
+
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			t.set(0)
+			return t.wrap(body), nil
+		}
+	}
+}
+
+// progressReader is an io.ReadCloser that feeds a progressTracker.
+type progressReader struct {
+	io.ReadCloser
+	tracker *progressTracker
+}
+
+// Read implements io.Reader.
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.tracker.add(n, err == io.EOF)
+	return n, err
+}
+
+// readerSize returns the number of bytes left in r, or -1 if it cannot be
+// determined without consuming it.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := v.Seek(cur, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - cur
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,223 @@
+package bunnystream
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// progressRecorder collects progress reports.
+type progressRecorder struct {
+	mu      sync.Mutex
+	reports []UploadProgress
+}
+
+func (r *progressRecorder) record(p UploadProgress) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, p)
+}
+
+func (r *progressRecorder) last(t *testing.T) UploadProgress {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.reports) == 0 {
+		t.Fatal("no progress reported")
+	}
+	return r.reports[len(r.reports)-1]
+}
+
+// -----------------------------------------------------------------------------
+// WithProgress — UploadVideo
+// -----------------------------------------------------------------------------
+
+func TestWithProgress_UploadVideoReportsCompletion(t *testing.T) {
+	c, srv := inspectServer(t, func(r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}, http.StatusOK)
+	defer srv.Close()
+
+	var rec progressRecorder
+	_, err := c.UploadVideo(context.Background(), "video-abc", strings.NewReader("fake-video-data"),
+		WithProgress(rec.record))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := rec.last(t)
+	if got.BytesSent != 15 || got.TotalBytes != 15 {
+		t.Errorf("last report = %+v, want 15/15 bytes", got)
+	}
+	if got.Percent() != 100 {
+		t.Errorf("Percent = %v, want 100", got.Percent())
+	}
+}
+
+func TestWithProgress_UploadVideoUnknownSize(t *testing.T) {
+	c, srv := inspectServer(t, func(r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}, http.StatusOK)
+	defer srv.Close()
+
+	var rec progressRecorder
+	body := io.MultiReader(strings.NewReader("fake-video-data"))
+	c.UploadVideo(context.Background(), "video-abc", body, WithProgress(rec.record))
+
+	got := rec.last(t)
+	if got.BytesSent != 15 || got.TotalBytes != -1 {
+		t.Errorf("last report = %+v, want 15 bytes of unknown total", got)
+	}
+	if got.Percent() != -1 || got.ETA != 0 {
+		t.Errorf("Percent/ETA = %v/%v, want -1/0 for unknown total", got.Percent(), got.ETA)
+	}
+}
+
+func TestWithProgress_UploadVideoRetryRestartsCount(t *testing.T) {
+	c, srv, _ := sequenceServer(t, []int{http.StatusServiceUnavailable, http.StatusOK}, nil)
+	defer srv.Close()
+
+	var rec progressRecorder
+	_, err := c.UploadVideo(context.Background(), "video-abc", bytes.NewReader(payload(40)),
+		WithProgress(rec.record))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := rec.last(t)
+	if got.BytesSent != 40 {
+		t.Errorf("BytesSent after retry = %d, want 40 (not double counted)", got.BytesSent)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithProgress — UploadVideoResumable
+// -----------------------------------------------------------------------------
+
+func TestWithProgress_ResumableReportsEveryChunk(t *testing.T) {
+	c, srv, _ := tusServer(t)
+	defer srv.Close()
+
+	var rec progressRecorder
+	_, err := c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(payload(100)), 100,
+		ChunkSize(30), WithProgress(rec.record))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var prev int64
+	for _, p := range rec.reports {
+		if p.BytesSent < prev {
+			t.Errorf("progress went backwards: %d after %d", p.BytesSent, prev)
+		}
+		if p.TotalBytes != 100 {
+			t.Errorf("TotalBytes = %d, want 100", p.TotalBytes)
+		}
+		prev = p.BytesSent
+	}
+	if len(rec.reports) < 4 {
+		t.Errorf("reports = %d, want at least one per chunk", len(rec.reports))
+	}
+	if got := rec.last(t); got.BytesSent != 100 {
+		t.Errorf("last BytesSent = %d, want 100", got.BytesSent)
+	}
+}
+
+func TestWithProgress_ResumableStartsFromStoredOffset(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+
+	data := payload(100)
+	standIn.data = append([]byte(nil), data[:60]...)
+	standIn.length = 100
+
+	var rec progressRecorder
+	c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(data), 100,
+		ResumeFrom(srv.URL+"/tusupload/upload-1"), WithProgress(rec.record))
+
+	if first := rec.reports[0]; first.BytesSent <= 60 {
+		t.Errorf("first BytesSent = %d, want above the 60 stored bytes", first.BytesSent)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// progressTracker
+// -----------------------------------------------------------------------------
+
+func TestProgressTracker_ThroughputAndETA(t *testing.T) {
+	var got UploadProgress
+	tracker := newProgressTracker(func(p UploadProgress) { got = p }, 1000)
+	tracker.start = time.Now().Add(-2 * time.Second)
+	tracker.set(200)
+	tracker.add(200, true)
+
+	// 200 bytes in ~2s past the 200 bytes already stored.
+	if got.BytesPerSecond < 90 || got.BytesPerSecond > 110 {
+		t.Errorf("BytesPerSecond = %v, want about 100", got.BytesPerSecond)
+	}
+	if got.ETA < 5*time.Second || got.ETA > 7*time.Second {
+		t.Errorf("ETA = %v, want about 6s", got.ETA)
+	}
+}
+
+func TestProgressTracker_Throttles(t *testing.T) {
+	calls := 0
+	tracker := newProgressTracker(func(UploadProgress) { calls++ }, -1)
+
+	for range 100 {
+		tracker.add(1, false)
+	}
+
+	if calls != 1 {
+		t.Errorf("reports = %d, want 1 within the throttle interval", calls)
+	}
+}
+
+func TestProgressTracker_NilIsNoop(t *testing.T) {
+	tracker := newProgressTracker(nil, 10)
+	if tracker != nil {
+		t.Fatal("expected nil tracker without callback")
+	}
+
+	tracker.set(5)
+	tracker.add(5, true)
+	body := io.NopCloser(strings.NewReader("x"))
+	if tracker.wrap(body) != body {
+		t.Error("nil tracker should not wrap the body")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// readerSize
+// -----------------------------------------------------------------------------
+
+func TestReaderSize(t *testing.T) {
+	seeked := strings.NewReader("0123456789")
+	seeked.Seek(4, io.SeekStart)
+
+	tests := []struct {
+		name string
+		r    io.Reader
+		want int64
+	}{
+		{"strings reader", strings.NewReader("abc"), 3},
+		{"bytes buffer", bytes.NewBufferString("abcd"), 4},
+		{"partially read seeker", io.NewSectionReader(strings.NewReader("0123456789"), 2, 5), 5},
+		{"unknown", io.MultiReader(strings.NewReader("abc")), -1},
+	}
+
+	for _, tt := range tests {
+		if got := readerSize(tt.r); got != tt.want {
+			t.Errorf("%s: readerSize = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	if got := readerSize(seeked); got != 6 {
+		t.Errorf("seeked reader: readerSize = %d, want 6", got)
+	}
+}
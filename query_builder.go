@@ -45,6 +45,14 @@ func (q *queryBuilder) setString(key, v string) *queryBuilder {
 	return q
 }
 
+// setInt adds an integer query param if the value is positive.
+func (q *queryBuilder) setInt(key string, v int) *queryBuilder {
+	if v > 0 {
+		q.values.Set(key, strconv.Itoa(v))
+	}
+	return q
+}
+
 // setStrings joins a string slice with commas and adds it as a query param
 // if the slice is non-empty.
 func (q *queryBuilder) setStrings(key string, v []string) *queryBuilder {
@@ -137,6 +137,38 @@ func TestSetString(t *testing.T) {
 	}
 }
 
+// -----------------------------------------------------------------------------
+// setInt
+// -----------------------------------------------------------------------------
+
+func TestSetInt(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     int
+		wantSet   bool
+		wantValue string
+	}{
+		{name: "positive value sets param", input: 25, wantSet: true, wantValue: "25"},
+		{name: "zero omits param", input: 0, wantSet: false},
+		{name: "negative value omits param", input: -1, wantSet: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mustNewRequest(t)
+			buildQuery(req).setInt("page", tt.input).apply()
+
+			got := req.URL.Query().Get("page")
+			if tt.wantSet && got != tt.wantValue {
+				t.Errorf("page = %q, want %q", got, tt.wantValue)
+			}
+			if !tt.wantSet && got != "" {
+				t.Errorf("expected page to be absent, got %q", got)
+			}
+		})
+	}
+}
+
 // -----------------------------------------------------------------------------
 // setStrings
 // -----------------------------------------------------------------------------
@@ -0,0 +1,150 @@
+package bunnystream
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a token bucket pacing requests made by a Client.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained request rate. Zero or less disables
+	// the limit.
+	RequestsPerSecond float64
+
+	// Burst is the number of requests that may be sent at once after a
+	// quiet period. Defaults to RequestsPerSecond rounded up, at least 1.
+	Burst int
+}
+
+// Adaptive throttling parameters: a 429 halves the rate, down to
+// minRateFactor of the configured limit, and every success wins back
+// recoverFactor of it.
+const (
+	minRateFactor = 0.1
+	recoverFactor = 0.05
+)
+
+// limiter is a token bucket shared by every goroutine using a Client. It
+// slows down when the API answers 429 Too Many Requests and recovers as
+// requests succeed again.
+type limiter struct {
+	mu          sync.Mutex
+	limit       float64 // configured rate, tokens per second
+	rate        float64 // current rate, lowered after 429s
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	maxPause    time.Duration // cap on a Retry-After pause
+}
+
+// newLimiter returns a limiter for cfg, or nil if cfg disables limiting.
+// Pauses requested by Retry-After are capped at maxPause.
+func newLimiter(cfg RateLimit, maxPause time.Duration) *limiter {
+	if cfg.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	burst := cfg.Burst
+	if burst < 1 {
+		burst = max(int(math.Ceil(cfg.RequestsPerSecond)), 1)
+	}
+
+	return &limiter{
+		limit:    cfg.RequestsPerSecond,
+		rate:     cfg.RequestsPerSecond,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+		maxPause: maxPause,
+	}
+}
+
+// refill adds the tokens earned since the last call. Callers hold l.mu.
+func (l *limiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+}
+
+// wait blocks until a request may be sent or ctx is done.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.refill(now)
+
+		var delay time.Duration
+		switch {
+		case now.Before(l.pausedUntil):
+			delay = l.pausedUntil.Sub(now)
+		case l.tokens >= 1:
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		default:
+			delay = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		}
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// observe adapts the rate to the outcome of a request. A 429 halves it and
+// pauses the bucket for the Retry-After delay, capped at maxPause. A success
+// recovers part of the configured rate.
+func (l *limiter) observe(resp *Response) {
+	if l == nil || resp == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		if resp.StatusCode < 400 && l.rate < l.limit {
+			l.rate = min(l.limit, l.rate+l.limit*recoverFactor)
+		}
+		return
+	}
+
+	now := time.Now()
+	l.refill(now)
+	l.rate = max(l.rate/2, l.limit*minRateFactor)
+	l.tokens = 0
+
+	pause, ok := parseRetryAfter(resp.Headers.Get("Retry-After"), now)
+	if !ok {
+		pause = time.Duration(float64(time.Second) / l.rate)
+	}
+	pause = min(pause, l.maxPause)
+	if until := now.Add(pause); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// limiterFor returns the limiter whose budget req is counted against:
+// uploads (video PUTs and TUS requests) or every other API call.
+func (c *Client) limiterFor(req *http.Request) *limiter {
+	if isUploadRequest(req) {
+		return c.uploadLimiter
+	}
+	return c.limiter
+}
+
+// isUploadRequest reports whether req transfers video data.
+func isUploadRequest(req *http.Request) bool {
+	return req.Method == http.MethodPut || strings.Contains(req.URL.Path, "/tusupload")
+}
@@ -0,0 +1,193 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// limiter
+// -----------------------------------------------------------------------------
+
+func TestNewLimiter_Defaults(t *testing.T) {
+	if l := newLimiter(RateLimit{}, time.Minute); l != nil {
+		t.Error("zero RateLimit should disable the limiter")
+	}
+	if err := (*limiter)(nil).wait(context.Background()); err != nil {
+		t.Errorf("nil limiter wait = %v, want nil", err)
+	}
+
+	if l := newLimiter(RateLimit{RequestsPerSecond: 2.5}, time.Minute); l.burst != 3 {
+		t.Errorf("burst = %v, want 3", l.burst)
+	}
+	if l := newLimiter(RateLimit{RequestsPerSecond: 0.2}, time.Minute); l.burst != 1 {
+		t.Errorf("burst = %v, want 1", l.burst)
+	}
+}
+
+func TestLimiter_PacesAfterBurst(t *testing.T) {
+	l := newLimiter(RateLimit{RequestsPerSecond: 100, Burst: 2}, time.Minute)
+
+	start := time.Now()
+	for range 5 {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+
+	// Two tokens are available at once, the next three take 10ms each.
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("5 requests took %v, want at least ~30ms", elapsed)
+	}
+}
+
+func TestLimiter_SharedAcrossGoroutines(t *testing.T) {
+	l := newLimiter(RateLimit{RequestsPerSecond: 200, Burst: 1}, time.Minute)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() { l.wait(context.Background()) })
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("10 concurrent requests took %v, want at least ~45ms", elapsed)
+	}
+}
+
+func TestLimiter_WaitRespectsContext(t *testing.T) {
+	l := newLimiter(RateLimit{RequestsPerSecond: 0.01, Burst: 1}, time.Minute)
+	l.wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestLimiter_AdaptsToRateLimiting(t *testing.T) {
+	l := newLimiter(RateLimit{RequestsPerSecond: 10}, time.Minute)
+
+	throttled := &Response{StatusCode: http.StatusTooManyRequests, Headers: http.Header{"Retry-After": {"2"}}}
+	l.observe(throttled)
+
+	if l.rate != 5 {
+		t.Errorf("rate after 429 = %v, want 5", l.rate)
+	}
+	if l.tokens != 0 {
+		t.Errorf("tokens after 429 = %v, want 0", l.tokens)
+	}
+	if pause := time.Until(l.pausedUntil); pause < time.Second || pause > 2*time.Second {
+		t.Errorf("pause = %v, want ~2s from Retry-After", pause)
+	}
+
+	for range 10 {
+		l.observe(throttled)
+	}
+	if l.rate != 1 {
+		t.Errorf("rate after many 429s = %v, want the 1 req/s floor", l.rate)
+	}
+
+	ok := &Response{StatusCode: http.StatusOK, Headers: http.Header{}}
+	for range 100 {
+		l.observe(ok)
+	}
+	if l.rate != 10 {
+		t.Errorf("rate after successes = %v, want it back at 10", l.rate)
+	}
+}
+
+func TestLimiter_CapsRetryAfterPause(t *testing.T) {
+	l := newLimiter(RateLimit{RequestsPerSecond: 10}, 50*time.Millisecond)
+
+	l.observe(&Response{StatusCode: http.StatusTooManyRequests, Headers: http.Header{"Retry-After": {"3600"}}})
+
+	if pause := time.Until(l.pausedUntil); pause > 50*time.Millisecond {
+		t.Errorf("pause = %v, want at most RetryWaitMax (50ms)", pause)
+	}
+}
+
+func TestRateLimit_PauseCappedAtRetryWaitMax(t *testing.T) {
+	c := mustNewClient(t, &Config{
+		APIKey:       "test-key",
+		LibraryID:    "123",
+		RateLimit:    RateLimit{RequestsPerSecond: 10},
+		RetryWaitMax: 20 * time.Millisecond,
+	})
+
+	c.limiter.observe(&Response{StatusCode: http.StatusTooManyRequests, Headers: http.Header{"Retry-After": {"3600"}}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.limiter.wait(ctx); err != nil {
+		t.Errorf("wait after Retry-After: 3600 = %v, want the pause capped at RetryWaitMax", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Client integration
+// -----------------------------------------------------------------------------
+
+func TestRateLimit_SeparateUploadBudget(t *testing.T) {
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	defer srv.Close()
+	c.limiter = newLimiter(RateLimit{RequestsPerSecond: 0.01, Burst: 1}, time.Minute)
+	c.uploadLimiter = newLimiter(RateLimit{RequestsPerSecond: 0.01, Burst: 1}, time.Minute)
+
+	if _, _, err := c.GetVideo(context.Background(), "video-abc"); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+
+	// The metadata budget is spent; an upload still has its own token.
+	if _, err := c.UploadVideo(context.Background(), "video-abc", strings.NewReader("data")); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := c.GetVideo(ctx, "video-abc"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second call = %v, want context.DeadlineExceeded while waiting for a token", err)
+	}
+}
+
+func TestRateLimit_ConfiguredFromConfig(t *testing.T) {
+	cfg := baseConfig()
+	cfg.RateLimit = RateLimit{RequestsPerSecond: 5}
+	c := mustNewClient(t, cfg)
+
+	if c.limiter == nil || c.limiter.limit != 5 {
+		t.Errorf("limiter = %+v, want 5 req/s", c.limiter)
+	}
+	if c.uploadLimiter != nil {
+		t.Error("upload limiter should be disabled by default")
+	}
+}
+
+func TestIsUploadRequest(t *testing.T) {
+	tests := []struct {
+		method, url string
+		want        bool
+	}{
+		{http.MethodPut, "https://video.bunnycdn.com/library/1/videos/abc", true},
+		{http.MethodPatch, "https://video.bunnycdn.com/tusupload/xyz", true},
+		{http.MethodPost, "https://video.bunnycdn.com/tusupload", true},
+		{http.MethodGet, "https://video.bunnycdn.com/library/1/videos/abc", false},
+		{http.MethodPost, "https://video.bunnycdn.com/library/1/videos/abc", false},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, tt.url, nil)
+		if got := isUploadRequest(req); got != tt.want {
+			t.Errorf("isUploadRequest(%s %s) = %v, want %v", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
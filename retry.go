@@ -0,0 +1,219 @@
+package bunnystream
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setReplayableBody installs a GetBody function for seekable request bodies
+// (such as *os.File) that net/http cannot rewind on its own, and sets the
+// Content-Length from the bytes left in them. The body is wrapped so the
+// transport does not close the caller's reader between attempts.
+func setReplayableBody(req *http.Request, body io.Reader) {
+	if body == nil || req.GetBody != nil {
+		return
+	}
+
+	seeker, ok := body.(io.Seeker)
+	if !ok {
+		return
+	}
+
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		// Not actually seekable (e.g. a pipe); leave it single-shot.
+		return
+	}
+
+	if end, err := seeker.Seek(0, io.SeekEnd); err == nil {
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return
+		}
+		req.ContentLength = end - start
+	}
+
+	req.Body = io.NopCloser(body)
+	req.GetBody = func() (io.ReadCloser, error) {
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		return io.NopCloser(body), nil
+	}
+}
+
+// rewindRequest returns a copy of req with a fresh body, ready to be sent
+// again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.GetBody == nil {
+		return retry, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry.Body = body
+
+	return retry, nil
+}
+
+// shouldRetry reports whether a failed request may be sent again.
+func shouldRetry(req *http.Request, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	// A consumed body that cannot be rewound would be resent empty.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	// A POST that reached the server may have taken effect; sending it again
+	// could create a duplicate. Retry it only when the server refused it or
+	// it never left the client.
+	if req.Method == http.MethodPost {
+		return errors.Is(err, ErrRateLimited) ||
+			errors.Is(err, ErrServiceUnavailable) ||
+			isDialError(err)
+	}
+
+	return isTransient(err)
+}
+
+// isTransient reports whether err is a rate limit, a server error or a
+// transport failure that may succeed when tried again.
+func isTransient(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.StatusCode)
+	}
+
+	if errors.Is(err, ErrRateLimited) ||
+		errors.Is(err, ErrServiceUnavailable) ||
+		errors.Is(err, ErrInternalServer) {
+		return true
+	}
+
+	// http.Client.Do reports transport failures as *url.Error.
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !isPermanentTransportError(err)
+}
+
+// retryableStatus reports whether an API response with the given status
+// may succeed when sent again. doRequest, IsTemporary and IsRetryable all
+// rely on it so they agree on what is worth retrying.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isDialError reports whether err happened while connecting, before any
+// part of the request was written.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isPermanentTransportError reports whether a transport failure fails the
+// same way on every attempt: an untrusted or invalid certificate, a peer
+// that does not speak TLS, or a URL scheme the client cannot handle.
+func isPermanentTransportError(err error) bool {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		invalidCert      x509.CertificateInvalidError
+		hostname         x509.HostnameError
+		recordHeader     tls.RecordHeaderError
+		verification     *tls.CertificateVerificationError
+	)
+	return errors.As(err, &unknownAuthority) ||
+		errors.As(err, &invalidCert) ||
+		errors.As(err, &hostname) ||
+		errors.As(err, &recordHeader) ||
+		errors.As(err, &verification) ||
+		strings.Contains(err.Error(), "unsupported protocol scheme")
+}
+
+// retryDelay returns how long to wait before the given retry attempt
+// (zero-based). It grows exponentially from RetryWaitMin with jitter, up to
+// RetryWaitMax, and is extended to honor a Retry-After header. It reports
+// false when Retry-After asks for more than RetryWaitMax: retrying earlier
+// would fail again, so the caller gives up and returns the error, whose
+// RetryAfter lets the application reschedule.
+func (c *Client) retryDelay(attempt int, resp *Response) (time.Duration, bool) {
+	minWait, maxWait := c.config.RetryWaitMin, c.config.RetryWaitMax
+
+	wait := maxWait
+	if attempt < 32 {
+		if backoff := minWait << attempt; backoff > 0 && backoff < maxWait {
+			wait = backoff
+		}
+	}
+
+	// Equal jitter: keep half of the delay, randomize the other half.
+	wait = wait/2 + rand.N(wait/2+1)
+
+	if resp != nil {
+		if after, ok := parseRetryAfter(resp.Headers.Get("Retry-After"), time.Now()); ok && after > wait {
+			if after > maxWait {
+				return 0, false
+			}
+			wait = after
+		}
+	}
+
+	return min(wait, maxWait), true
+}
+
+// parseRetryAfter parses a Retry-After header value given either in seconds
+// or as an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(v); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d to elapse or ctx to be done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
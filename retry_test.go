@@ -0,0 +1,463 @@
+package bunnystream
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Helpers
+// -----------------------------------------------------------------------------
+
+// sequenceServer creates a fake HTTP server that answers with the given status
+// codes and an empty JSON object, in order, repeating the last status once the
+// list is exhausted. Every
+// request body is passed to onBody. Returns a client with near-zero retry
+// waits and a counter of received requests.
+func sequenceServer(t *testing.T, statuses []int, onBody func(string)) (*Client, *httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1)) - 1
+		body, _ := io.ReadAll(r.Body)
+		if onBody != nil {
+			onBody(string(body))
+		}
+		w.WriteHeader(statuses[min(n, len(statuses)-1)])
+		w.Write([]byte(`{}`))
+	}))
+
+	cfg := &Config{
+		APIKey:       "test-key",
+		LibraryID:    "123",
+		BaseURL:      srv.URL,
+		HTTPClient:   srv.Client(),
+		MaxRetries:   3,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 5 * time.Millisecond,
+	}
+	client, err := NewClient(cfg)
+	if err != nil {
+		srv.Close()
+		t.Fatalf("failed to create test client: %v", err)
+	}
+
+	return client, srv, &calls
+}
+
+// -----------------------------------------------------------------------------
+// doRequest — retries
+// -----------------------------------------------------------------------------
+
+func TestDoRequest_RetriesTransientStatuses(t *testing.T) {
+	c, srv, calls := sequenceServer(t, []int{
+		http.StatusServiceUnavailable,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusGatewayTimeout,
+		http.StatusOK,
+	}, nil)
+	defer srv.Close()
+	c.config.MaxRetries = 5
+
+	_, _, err := c.GetVideo(context.Background(), "video-abc")
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if got := calls.Load(); got != 6 {
+		t.Errorf("requests = %d, want 6", got)
+	}
+}
+
+func TestDoRequest_StopsAfterMaxRetries(t *testing.T) {
+	c, srv, calls := sequenceServer(t, []int{http.StatusServiceUnavailable}, nil)
+	defer srv.Close()
+
+	_, _, err := c.CreateVideoObject(context.Background(), "My Video")
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("expected ErrServiceUnavailable, got %v", err)
+	}
+	if got := calls.Load(); got != 4 {
+		t.Errorf("requests = %d, want 4 (1 attempt + 3 retries)", got)
+	}
+}
+
+func TestDoRequest_DoesNotRetryClientErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusNotImplemented} {
+		c, srv, calls := sequenceServer(t, []int{status}, nil)
+
+		c.CreateVideoObject(context.Background(), "My Video")
+		if got := calls.Load(); got != 1 {
+			t.Errorf("status %d: requests = %d, want 1", status, got)
+		}
+		srv.Close()
+	}
+}
+
+func TestDoRequest_RetriesTransportErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// Drop the connection without answering.
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := mustNewClient(t, &Config{
+		APIKey:       "test-key",
+		LibraryID:    "123",
+		BaseURL:      srv.URL,
+		HTTPClient:   srv.Client(),
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+
+	if _, _, err := c.GetVideo(context.Background(), "video-abc"); err != nil {
+		t.Fatalf("expected success after transport error, got %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestDoRequest_POSTRetriesOnlyRefusals(t *testing.T) {
+	for _, tt := range []struct {
+		status, want int
+	}{
+		{http.StatusTooManyRequests, 2},
+		{http.StatusServiceUnavailable, 2},
+		{http.StatusInternalServerError, 1},
+		{http.StatusBadGateway, 1},
+	} {
+		c, srv, calls := sequenceServer(t, []int{tt.status, http.StatusOK}, nil)
+
+		c.CreateVideoObject(context.Background(), "My Video")
+		if got := calls.Load(); got != int32(tt.want) {
+			t.Errorf("status %d: requests = %d, want %d", tt.status, got, tt.want)
+		}
+		srv.Close()
+	}
+}
+
+func TestDoRequest_POSTNotRetriedAfterSending(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		// The request arrived; drop the connection before answering.
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer srv.Close()
+
+	c := mustNewClient(t, &Config{
+		APIKey:       "test-key",
+		LibraryID:    "123",
+		BaseURL:      srv.URL,
+		HTTPClient:   srv.Client(),
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+
+	if _, _, err := c.CreateVideoObject(context.Background(), "My Video"); err == nil {
+		t.Fatal("expected a transport error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestDoRequest_POSTRetriedAfterDialFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	var dials atomic.Int32
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if dials.Add(1) == 1 {
+				return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNREFUSED}
+			}
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+	defer transport.CloseIdleConnections()
+
+	c := mustNewClient(t, &Config{
+		APIKey:       "test-key",
+		LibraryID:    "123",
+		BaseURL:      srv.URL,
+		HTTPClient:   &http.Client{Transport: transport},
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+
+	if _, _, err := c.CreateVideoObject(context.Background(), "My Video"); err != nil {
+		t.Fatalf("expected success after dial failure, got %v", err)
+	}
+	if got := dials.Load(); got != 2 {
+		t.Errorf("dials = %d, want 2", got)
+	}
+}
+
+func TestDoRequest_DoesNotRetryCertificateErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	var attempts atomic.Int32
+	c := mustNewClient(t, &Config{
+		APIKey:    "test-key",
+		LibraryID: "123",
+		BaseURL:   srv.URL,
+		// The default client does not trust the test server's certificate.
+		HTTPClient: &http.Client{},
+		Middleware: []Middleware{func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				attempts.Add(1)
+				return next.Do(req)
+			})
+		}},
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+
+	_, _, err := c.GetVideo(context.Background(), "video-abc")
+	if err == nil {
+		t.Fatal("expected a certificate error")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+	if calls.Load() != 0 {
+		t.Error("handler was reached despite the untrusted certificate")
+	}
+}
+
+func TestIsTransient_PermanentTransportErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"connection reset", transportErr(syscall.ECONNRESET), true},
+		{"unknown authority", transportErr(x509.UnknownAuthorityError{}), false},
+		{"hostname", transportErr(x509.HostnameError{Host: "example.com"}), false},
+		{"invalid certificate", transportErr(x509.CertificateInvalidError{Reason: x509.Expired}), false},
+		{"verification", transportErr(&tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}), false},
+		{"not tls", transportErr(tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}), false},
+		{"scheme", transportErr(errors.New(`unsupported protocol scheme "ftp"`)), false},
+	}
+	for _, tt := range tests {
+		if got := isTransient(tt.err); got != tt.want {
+			t.Errorf("%s: isTransient = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDoRequest_ReplaysJSONBody(t *testing.T) {
+	var bodies []string
+	c, srv, _ := sequenceServer(t, []int{http.StatusServiceUnavailable, http.StatusOK}, func(b string) {
+		bodies = append(bodies, b)
+	})
+	defer srv.Close()
+
+	c.CreateVideoObject(context.Background(), "My Video")
+
+	if len(bodies) != 2 {
+		t.Fatalf("requests = %d, want 2", len(bodies))
+	}
+	if bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("retried body = %q, want %q", bodies[1], bodies[0])
+	}
+}
+
+func TestDoRequest_ReplaysSeekableUploadBody(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "video-*.mp4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	f.WriteString("fake-video-data")
+	f.Seek(0, io.SeekStart)
+
+	var bodies []string
+	c, srv, _ := sequenceServer(t, []int{http.StatusInternalServerError, http.StatusOK}, func(b string) {
+		bodies = append(bodies, b)
+	})
+	defer srv.Close()
+
+	if _, err := c.UploadVideo(context.Background(), "video-abc", f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("requests = %d, want 2", len(bodies))
+	}
+	for i, b := range bodies {
+		if b != "fake-video-data" {
+			t.Errorf("attempt %d body = %q, want %q", i+1, b, "fake-video-data")
+		}
+	}
+}
+
+func TestDoRequest_DoesNotRetryUnreplayableBody(t *testing.T) {
+	c, srv, calls := sequenceServer(t, []int{http.StatusServiceUnavailable, http.StatusOK}, nil)
+	defer srv.Close()
+
+	// io.MultiReader hides the Seeker interface of the underlying reader.
+	body := io.MultiReader(strings.NewReader("fake-video-data"))
+	_, err := c.UploadVideo(context.Background(), "video-abc", body)
+
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("expected ErrServiceUnavailable, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestDoRequest_ContextCancelledDuringBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := mustNewClient(t, &Config{
+		APIKey:     "test-key",
+		LibraryID:  "123",
+		BaseURL:    srv.URL,
+		HTTPClient: srv.Client(),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := c.CreateVideoObject(ctx, "My Video")
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected error to wrap ErrRateLimited, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("backoff ignored context cancellation, took %v", elapsed)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// retryDelay / parseRetryAfter
+// -----------------------------------------------------------------------------
+
+func TestRetryDelay_StaysWithinBounds(t *testing.T) {
+	c := mustNewClient(t, &Config{
+		APIKey:       "test-key",
+		LibraryID:    "123",
+		RetryWaitMin: 100 * time.Millisecond,
+		RetryWaitMax: time.Second,
+	})
+
+	for attempt := 0; attempt < 40; attempt++ {
+		got, _ := c.retryDelay(attempt, nil)
+		if got < 50*time.Millisecond || got > time.Second {
+			t.Errorf("attempt %d: delay %v out of bounds", attempt, got)
+		}
+	}
+}
+
+func TestRetryDelay_HonorsRetryAfter(t *testing.T) {
+	c := mustNewClient(t, &Config{
+		APIKey:       "test-key",
+		LibraryID:    "123",
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Minute,
+	})
+
+	resp := &Response{Headers: http.Header{"Retry-After": []string{"7"}}}
+	if got, ok := c.retryDelay(0, resp); !ok || got != 7*time.Second {
+		t.Errorf("delay = %v, %v, want 7s, true", got, ok)
+	}
+}
+
+func TestRetryDelay_RetryAfterBeyondMaxGivesUp(t *testing.T) {
+	c := mustNewClient(t, &Config{
+		APIKey:       "test-key",
+		LibraryID:    "123",
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 2 * time.Second,
+	})
+
+	resp := &Response{Headers: http.Header{"Retry-After": []string{"3600"}}}
+	if got, ok := c.retryDelay(0, resp); ok {
+		t.Errorf("delay = %v, true, want no retry", got)
+	}
+}
+
+func TestDoRequest_ReturnsErrorWhenRetryAfterExceedsMax(t *testing.T) {
+	var calls atomic.Int32
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer srv.Close()
+
+	_, _, err := c.GetVideo(context.Background(), "video-abc")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Hour {
+		t.Fatalf("expected *APIError with RetryAfter 1h, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in     string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{now.Add(-30 * time.Second).Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.in, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = (%v, %v), want (%v, %v)", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
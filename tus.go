@@ -0,0 +1,292 @@
+package bunnystream
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults for UploadVideoResumable.
+const (
+	DefaultChunkSize    int64         = 8 << 20
+	DefaultUploadExpiry time.Duration = 24 * time.Hour
+)
+
+// tusVersion is the TUS protocol version spoken by Bunny's /tusupload endpoint.
+const tusVersion = "1.0.0"
+
+var (
+	// ErrUploadSizeRequired is returned when UploadVideoResumable is called
+	// with a size of zero or less.
+	ErrUploadSizeRequired = errors.New("upload size must be greater than 0")
+
+	// ErrUploadURLMissing is returned when the TUS server accepts a new
+	// upload without telling where to send it.
+	ErrUploadURLMissing = errors.New("tus server did not return an upload url")
+
+	// ErrUploadStalled is returned when the TUS server accepts a chunk but
+	// reports an offset that does not advance the upload or lies past its end.
+	ErrUploadStalled = errors.New("tus upload did not advance")
+)
+
+// tusAuth holds the per-upload authorization sent with every TUS request.
+type tusAuth struct {
+	videoID string
+	expire  int64
+}
+
+// tusSignature computes the TUS AuthorizationSignature header:
+// hex(SHA256(libraryID + apiKey + expire + videoID)).
+func tusSignature(libraryID, apiKey string, expire int64, videoID string) string {
+	hash := sha256.Sum256([]byte(libraryID + apiKey + strconv.FormatInt(expire, 10) + videoID))
+	return hex.EncodeToString(hash[:])
+}
+
+// UploadVideoResumable uploads a video with the TUS resumable upload protocol.
+//
+// The file is sent in chunks of ChunkSize bytes. When a chunk fails with a
+// rate limit, server or network error, the client asks the server how many
+// bytes it already stored and resumes from there, up to Config.MaxRetries
+// times in a row. Use this instead of UploadVideo for large files or
+// unreliable connections.
+//
+// r must yield exactly size bytes from its current position; it is seeked
+// as needed to resend data. To continue an upload after a restart, persist
+// the URL passed to OnUploadCreated and pass it back with ResumeFrom.
+//
+// Encoding options such as JITEnabled only apply to UploadVideo and are
+// ignored here.
+func (c *Client) UploadVideoResumable(ctx context.Context, videoID string, r io.ReadSeeker, size int64, opts ...UploadVideoOption) (*Response, error) {
+	if strings.TrimSpace(videoID) == "" {
+		return nil, ErrVideoIDRequired
+	}
+
+	if size < 1 {
+		return nil, ErrUploadSizeRequired
+	}
+
+	options := &UploadVideoOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	chunkSize := options.chunkSize
+	if chunkSize < 1 {
+		chunkSize = DefaultChunkSize
+	}
+
+	expiry := options.uploadExpiry
+	if expiry < 1 {
+		expiry = DefaultUploadExpiry
+	}
+
+	// Offsets are relative to where the reader stands now.
+	base, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seek upload body: %w", err)
+	}
+
+	auth := tusAuth{videoID: videoID, expire: time.Now().Add(expiry).Unix()}
+
+	var (
+		resp      *Response
+		offset    int64
+		uploadURL = options.resumeURL
+	)
+
+	if uploadURL == "" {
+		if uploadURL, err = c.tusCreate(ctx, auth, size, options); err != nil {
+			return nil, err
+		}
+		if options.onUploadCreated != nil {
+			options.onUploadCreated(uploadURL)
+		}
+	} else {
+		if resp, offset, err = c.tusOffset(ctx, uploadURL, auth); err != nil {
+			return nil, err
+		}
+	}
+
+	progress := newProgressTracker(options.onProgress, size)
+
+	failures := 0
+	for offset < size {
+		progress.set(offset)
+
+		if _, err := r.Seek(base+offset, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to seek upload body: %w", err)
+		}
+
+		n := min(chunkSize, size-offset)
+
+		var next int64
+		chunk := progress.wrap(io.NopCloser(io.LimitReader(r, n)))
+		resp, next, err = c.tusPatch(ctx, uploadURL, auth, offset, chunk, n)
+		if err == nil && (next <= offset || next > size) {
+			// Resending would not move the upload forward.
+			return nil, fmt.Errorf("%w: server acknowledged offset %d after a chunk at offset %d of %d", ErrUploadStalled, next, offset, size)
+		}
+		if err == nil {
+			offset = next
+			failures = 0
+			continue
+		}
+
+		if failures >= c.config.MaxRetries || ctx.Err() != nil || !(isTransient(err) || isOffsetConflict(err)) {
+			return nil, err
+		}
+
+		delay, ok := c.retryDelay(failures, resp)
+		if !ok {
+			return nil, err
+		}
+
+		if u, err := url.Parse(uploadURL); err == nil {
+			c.config.Metrics.ObserveRetry(c.endpoint(u), http.MethodPatch)
+		}
+
+		if waitErr := sleepContext(ctx, delay); waitErr != nil {
+			return nil, fmt.Errorf("%w: %w", waitErr, err)
+		}
+		failures++
+
+		// Ask the server how much of the chunk it kept before resuming.
+		if resp, offset, err = c.tusOffset(ctx, uploadURL, auth); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// tusRequest creates a request carrying the TUS protocol and authorization
+// headers.
+func (c *Client) tusRequest(ctx context.Context, method, uri string, body io.Reader, contentType string, auth tusAuth) (*http.Request, error) {
+	req, err := c.request(ctx, method, uri, body, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	// TUS requests are authorized by their signature alone. The upload URL
+	// comes from the server or the caller, so the API key must not travel.
+	req.Header.Del("AccessKey")
+
+	req.Header.Set("Tus-Resumable", tusVersion)
+	req.Header.Set("AuthorizationSignature", tusSignature(c.libraryID, c.apiKey, auth.expire, auth.videoID))
+	req.Header.Set("AuthorizationExpire", strconv.FormatInt(auth.expire, 10))
+	req.Header.Set("VideoId", auth.videoID)
+	req.Header.Set("LibraryId", c.libraryID)
+
+	return req, nil
+}
+
+// tusCreate starts a new TUS upload and returns its absolute upload URL.
+func (c *Client) tusCreate(ctx context.Context, auth tusAuth, size int64, options *UploadVideoOptions) (string, error) {
+	endpoint := c.buildURL("/tusupload")
+
+	req, err := c.tusRequest(ctx, http.MethodPost, endpoint, nil, "", auth)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Upload-Length", strconv.FormatInt(size, 10))
+
+	var metadata []string
+	if options.uploadFileType != "" {
+		metadata = append(metadata, "filetype "+base64.StdEncoding.EncodeToString([]byte(options.uploadFileType)))
+	}
+	if options.uploadTitle != "" {
+		metadata = append(metadata, "title "+base64.StdEncoding.EncodeToString([]byte(options.uploadTitle)))
+	}
+	if len(metadata) > 0 {
+		req.Header.Set("Upload-Metadata", strings.Join(metadata, ","))
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	location := resp.Headers.Get("Location")
+	if location == "" {
+		return "", ErrUploadURLMissing
+	}
+
+	ref, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("invalid upload url %q: %w", location, err)
+	}
+
+	return req.URL.ResolveReference(ref).String(), nil
+}
+
+// tusOffset asks the server how many bytes of the upload it has stored.
+func (c *Client) tusOffset(ctx context.Context, uploadURL string, auth tusAuth) (*Response, int64, error) {
+	req, err := c.tusRequest(ctx, http.MethodHead, uploadURL, nil, "", auth)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset, err := parseUploadOffset(resp)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp, offset, nil
+}
+
+// tusPatch sends n bytes of body starting at offset and returns the new
+// offset acknowledged by the server.
+func (c *Client) tusPatch(ctx context.Context, uploadURL string, auth tusAuth, offset int64, body io.Reader, n int64) (*Response, int64, error) {
+	req, err := c.tusRequest(ctx, http.MethodPatch, uploadURL, body, "application/offset+octet-stream", auth)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.ContentLength = n
+	req.Header.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return resp, 0, err
+	}
+
+	next, err := parseUploadOffset(resp)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp, next, nil
+}
+
+// parseUploadOffset reads the Upload-Offset header of a TUS response.
+func parseUploadOffset(resp *Response) (int64, error) {
+	v := resp.Headers.Get("Upload-Offset")
+	offset, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid Upload-Offset header %q", v)
+	}
+	return offset, nil
+}
+
+// isOffsetConflict reports whether the server rejected a chunk because its
+// offset did not match the stored upload, which is fixed by re-reading the
+// offset.
+func isOffsetConflict(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
@@ -0,0 +1,439 @@
+package bunnystream
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Helpers
+// -----------------------------------------------------------------------------
+
+// tusStandIn is a minimal in-memory TUS server mimicking Bunny's /tusupload
+// endpoint for a single upload.
+type tusStandIn struct {
+	mu sync.Mutex
+
+	data    []byte
+	length  int64
+	creates int
+	patches int
+
+	// accessKeys counts the requests carrying an AccessKey header.
+	accessKeys int
+
+	// createHeaders holds the headers of the last creation request.
+	createHeaders http.Header
+
+	// failPatch, when set, is called for every PATCH with its 1-based index.
+	// Returning a non-zero status stores only the first keep bytes of the
+	// chunk and answers with that status.
+	failPatch func(n int) (keep int, status int)
+
+	// ackOffset, when set, replaces the Upload-Offset acknowledged for a
+	// stored chunk; it receives the offset the server actually holds.
+	ackOffset func(stored int) int
+}
+
+func (s *tusStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("AccessKey") != "" {
+		s.accessKeys++
+	}
+
+	if r.Header.Get("Tus-Resumable") != tusVersion {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/tusupload":
+		s.creates++
+		s.createHeaders = r.Header.Clone()
+		s.length, _ = strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+		w.Header().Set("Location", "/tusupload/upload-1")
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodHead && r.URL.Path == "/tusupload/upload-1":
+		w.Header().Set("Upload-Offset", strconv.Itoa(len(s.data)))
+		w.Header().Set("Upload-Length", strconv.FormatInt(s.length, 10))
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodPatch && r.URL.Path == "/tusupload/upload-1":
+		s.patches++
+		if r.Header.Get("Upload-Offset") != strconv.Itoa(len(s.data)) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		chunk, _ := io.ReadAll(r.Body)
+		if s.failPatch != nil {
+			if keep, status := s.failPatch(s.patches); status != 0 {
+				s.data = append(s.data, chunk[:keep]...)
+				w.WriteHeader(status)
+				return
+			}
+		}
+
+		s.data = append(s.data, chunk...)
+		ack := len(s.data)
+		if s.ackOffset != nil {
+			ack = s.ackOffset(ack)
+		}
+		w.Header().Set("Upload-Offset", strconv.Itoa(ack))
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// tusServer starts a tusStandIn and returns a client talking to it.
+func tusServer(t *testing.T) (*Client, *httptest.Server, *tusStandIn) {
+	t.Helper()
+
+	standIn := &tusStandIn{}
+	srv := httptest.NewServer(standIn)
+
+	client := mustNewClient(t, &Config{
+		APIKey:       "test-key",
+		LibraryID:    "123",
+		BaseURL:      srv.URL,
+		HTTPClient:   srv.Client(),
+		MaxRetries:   2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+
+	return client, srv, standIn
+}
+
+// payload returns n bytes of deterministic test data.
+func payload(n int) []byte {
+	return bytes.Repeat([]byte("0123456789"), n/10+1)[:n]
+}
+
+// -----------------------------------------------------------------------------
+// UploadVideoResumable
+// -----------------------------------------------------------------------------
+
+func TestUploadVideoResumable_UploadsInChunks(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+
+	data := payload(100)
+	var createdURL string
+
+	_, err := c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(data), 100,
+		ChunkSize(30),
+		OnUploadCreated(func(u string) { createdURL = u }),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(standIn.data, data) {
+		t.Errorf("server stored %q, want %q", standIn.data, data)
+	}
+	if standIn.patches != 4 {
+		t.Errorf("PATCH requests = %d, want 4", standIn.patches)
+	}
+	if want := srv.URL + "/tusupload/upload-1"; createdURL != want {
+		t.Errorf("OnUploadCreated URL = %q, want %q", createdURL, want)
+	}
+}
+
+func TestUploadVideoResumable_SendsAuthorizationHeaders(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+
+	c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(payload(10)), 10,
+		UploadTitle("My Video"),
+		UploadFileType("video/mp4"),
+	)
+
+	h := standIn.createHeaders
+	if h.Get("VideoId") != "video-abc" || h.Get("LibraryId") != "123" {
+		t.Errorf("VideoId/LibraryId = %q/%q", h.Get("VideoId"), h.Get("LibraryId"))
+	}
+	if h.Get("Upload-Length") != "10" {
+		t.Errorf("Upload-Length = %q, want 10", h.Get("Upload-Length"))
+	}
+
+	expire, err := strconv.ParseInt(h.Get("AuthorizationExpire"), 10, 64)
+	if err != nil || expire <= time.Now().Unix() {
+		t.Fatalf("AuthorizationExpire = %q, want a future unix timestamp", h.Get("AuthorizationExpire"))
+	}
+	if want := tusSignature("123", "test-key", expire, "video-abc"); h.Get("AuthorizationSignature") != want {
+		t.Errorf("AuthorizationSignature = %q, want %q", h.Get("AuthorizationSignature"), want)
+	}
+
+	meta := h.Get("Upload-Metadata")
+	if !strings.Contains(meta, "filetype dmlkZW8vbXA0") || !strings.Contains(meta, "title TXkgVmlkZW8=") {
+		t.Errorf("Upload-Metadata = %q, want base64 filetype and title", meta)
+	}
+}
+
+func TestUploadVideoResumable_NeverSendsAccessKey(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+
+	// A failure forces offset recovery, so every TUS request type is sent.
+	standIn.failPatch = func(n int) (int, int) {
+		if n == 1 {
+			return 10, http.StatusServiceUnavailable
+		}
+		return 0, 0
+	}
+
+	data := payload(50)
+	if _, err := c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(data), 50, ChunkSize(30)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if standIn.accessKeys != 0 {
+		t.Errorf("%d TUS requests carried AccessKey, want none", standIn.accessKeys)
+	}
+}
+
+func TestUploadVideoResumable_ForeignLocationGetsNoAccessKey(t *testing.T) {
+	foreign := &tusStandIn{}
+	foreignSrv := httptest.NewServer(foreign)
+	defer foreignSrv.Close()
+
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", foreignSrv.URL+"/tusupload/upload-1")
+		w.WriteHeader(http.StatusCreated)
+	})
+	defer srv.Close()
+
+	// The stand-in never saw the creation request, so it rejects the
+	// upload; only the headers it received matter here.
+	c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(payload(10)), 10)
+
+	if foreign.patches == 0 {
+		t.Fatal("expected the upload to be sent to the Location server")
+	}
+	if foreign.accessKeys != 0 {
+		t.Errorf("Location server received AccessKey in %d requests", foreign.accessKeys)
+	}
+}
+
+func TestUploadVideoResumable_ResumesAfterTransientFailure(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+
+	// The second chunk is cut short half way through.
+	standIn.failPatch = func(n int) (int, int) {
+		if n == 2 {
+			return 15, http.StatusServiceUnavailable
+		}
+		return 0, 0
+	}
+
+	data := payload(100)
+	_, err := c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(data), 100, ChunkSize(30))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(standIn.data, data) {
+		t.Errorf("server stored %q, want %q", standIn.data, data)
+	}
+}
+
+func TestUploadVideoResumable_RecoversFromOffsetConflict(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+
+	// Another writer already stored the first bytes, so the first PATCH at
+	// offset 0 is rejected with 409 Conflict.
+	data := payload(50)
+	standIn.data = append([]byte(nil), data[:10]...)
+
+	_, err := c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(data), 50, ChunkSize(20))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(standIn.data, data) {
+		t.Errorf("server stored %q, want %q", standIn.data, data)
+	}
+}
+
+func TestUploadVideoResumable_ResumeFromAfterFailure(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+
+	// The server stores the first chunk but the acknowledgement is lost.
+	standIn.failPatch = func(n int) (int, int) {
+		if n == 1 {
+			return 30, http.StatusNotImplemented
+		}
+		return 0, 0
+	}
+
+	data := payload(60)
+	_, err := c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(data), 60, ChunkSize(30))
+
+	// 501 is not transient, so the upload must stop without retrying.
+	if err == nil {
+		t.Fatal("expected error for non-transient failure, got nil")
+	}
+	if standIn.patches != 1 {
+		t.Errorf("PATCH requests = %d, want 1", standIn.patches)
+	}
+
+	// Resuming the same session picks up after the stored chunk.
+	_, err = c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(data), 60,
+		ChunkSize(30),
+		ResumeFrom(srv.URL+"/tusupload/upload-1"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error on resume: %v", err)
+	}
+	if !bytes.Equal(standIn.data, data) {
+		t.Errorf("server stored %q, want %q", standIn.data, data)
+	}
+	if standIn.creates != 1 {
+		t.Errorf("creation requests = %d, want 1", standIn.creates)
+	}
+}
+
+func TestUploadVideoResumable_ResumeFromSkipsStoredBytes(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+
+	data := payload(100)
+	standIn.data = append([]byte(nil), data[:40]...)
+	standIn.length = 100
+
+	_, err := c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(data), 100,
+		ChunkSize(30),
+		ResumeFrom(srv.URL+"/tusupload/upload-1"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if standIn.creates != 0 {
+		t.Errorf("creation requests = %d, want 0 when resuming", standIn.creates)
+	}
+	if standIn.patches != 2 {
+		t.Errorf("PATCH requests = %d, want 2", standIn.patches)
+	}
+	if !bytes.Equal(standIn.data, data) {
+		t.Errorf("server stored %q, want %q", standIn.data, data)
+	}
+}
+
+func TestUploadVideoResumable_ReaderOffsetIsRespected(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+
+	r := strings.NewReader("HEADER" + "video-bytes")
+	r.Seek(6, io.SeekStart)
+
+	if _, err := c.UploadVideoResumable(context.Background(), "video-abc", r, 11, ChunkSize(4)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(standIn.data) != "video-bytes" {
+		t.Errorf("server stored %q, want %q", standIn.data, "video-bytes")
+	}
+}
+
+func TestUploadVideoResumable_GivesUpAfterMaxRetries(t *testing.T) {
+	c, srv, standIn := tusServer(t)
+	defer srv.Close()
+
+	standIn.failPatch = func(n int) (int, int) {
+		return 0, http.StatusServiceUnavailable
+	}
+
+	_, err := c.UploadVideoResumable(context.Background(), "video-abc", bytes.NewReader(payload(10)), 10)
+
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("expected ErrServiceUnavailable, got %v", err)
+	}
+	if standIn.patches != 3 {
+		t.Errorf("PATCH requests = %d, want 3 (1 attempt + 2 retries)", standIn.patches)
+	}
+}
+
+func TestUploadVideoResumable_FailsWhenOffsetDoesNotAdvance(t *testing.T) {
+	cases := []struct {
+		name string
+		ack  func(stored int) int
+	}{
+		{"stalled", func(int) int { return 0 }},
+		{"past end", func(stored int) int { return stored + 100 }},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			c, srv, standIn := tusServer(t)
+			defer srv.Close()
+			standIn.ackOffset = tt.ack
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err := c.UploadVideoResumable(ctx, "video-abc", bytes.NewReader(payload(10)), 10, ChunkSize(4))
+
+			if !errors.Is(err, ErrUploadStalled) {
+				t.Errorf("expected ErrUploadStalled, got %v", err)
+			}
+			if standIn.patches != 1 {
+				t.Errorf("PATCH requests = %d, want 1", standIn.patches)
+			}
+		})
+	}
+}
+
+func TestUploadVideoResumable_Validation(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+
+	if _, err := c.UploadVideoResumable(context.Background(), "", strings.NewReader("x"), 1); !errors.Is(err, ErrVideoIDRequired) {
+		t.Errorf("expected ErrVideoIDRequired, got %v", err)
+	}
+	if _, err := c.UploadVideoResumable(context.Background(), "video-abc", strings.NewReader(""), 0); !errors.Is(err, ErrUploadSizeRequired) {
+		t.Errorf("expected ErrUploadSizeRequired, got %v", err)
+	}
+}
+
+func TestUploadVideoResumable_MissingLocation(t *testing.T) {
+	c, srv := testServer(t, http.StatusCreated, "")
+	defer srv.Close()
+
+	_, err := c.UploadVideoResumable(context.Background(), "video-abc", strings.NewReader("x"), 1)
+	if !errors.Is(err, ErrUploadURLMissing) {
+		t.Errorf("expected ErrUploadURLMissing, got %v", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// tusSignature
+// -----------------------------------------------------------------------------
+
+func TestTUSSignature_KnownValue(t *testing.T) {
+	// sha256("123" + "key" + "1700000000" + "video-abc")
+	got := tusSignature("123", "key", 1700000000, "video-abc")
+	want := "99e1b89d28d8c098dcceb2c1b7d576e9c4931574582574fcc4c14388044ced2f"
+	if got != want {
+		t.Errorf("tusSignature = %q, want %q", got, want)
+	}
+	if got == tusSignature("123", "key", 1700000001, "video-abc") {
+		t.Error("signature does not depend on the expiration time")
+	}
+}
@@ -0,0 +1,71 @@
+package bunnystream
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// VideoUpdate lists the video fields to change with UpdateVideo.
+//
+// Only non-nil fields are sent, so anything left nil keeps its current value.
+// Set a slice field to a pointer to an empty slice to clear it.
+//
+//	client.UpdateVideo(ctx, videoID, bunnystream.VideoUpdate{
+//	    Title:    bunnystream.Ptr("New title"),
+//	    MetaTags: &[]bunnystream.MetaTag{{Property: "sha256", Value: sum}},
+//	})
+type VideoUpdate struct {
+	// Title is the new display name of the video. Must not be blank.
+	Title *string `json:"title,omitempty"`
+
+	// CollectionID moves the video to another collection.
+	CollectionID *string `json:"collectionId,omitempty"`
+
+	// Chapters replaces the chapters of the video.
+	Chapters *[]Chapter `json:"chapters,omitempty"`
+
+	// Moments replaces the moments of the video.
+	Moments *[]Moment `json:"moments,omitempty"`
+
+	// MetaTags replaces the custom meta tags of the video.
+	MetaTags *[]MetaTag `json:"metaTags,omitempty"`
+}
+
+// Ptr returns a pointer to v. Handy for filling optional fields such as
+// those of VideoUpdate.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// UpdateVideo changes the metadata of an existing video.
+//
+// Only the fields set in update are modified; see VideoUpdate.
+func (c *Client) UpdateVideo(ctx context.Context, videoID string, update VideoUpdate) (*Response, error) {
+	if strings.TrimSpace(videoID) == "" {
+		return nil, ErrVideoIDRequired
+	}
+
+	if update.Title != nil && strings.TrimSpace(*update.Title) == "" {
+		return nil, ErrTitleRequired
+	}
+
+	endpoint := c.buildURL("/library/%v/videos/%v", c.libraryID, videoID)
+
+	bodyBuf, err := c.encodeJSON(update)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.request(ctx, http.MethodPost, endpoint, bodyBuf, "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
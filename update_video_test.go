@@ -0,0 +1,124 @@
+package bunnystream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// captureBody returns an inspect function that decodes the JSON request body
+// into dst.
+func captureBody(t *testing.T, dst *map[string]json.RawMessage) func(*http.Request) {
+	t.Helper()
+	return func(r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, dst); err != nil {
+			t.Errorf("request body is not a JSON object: %q", body)
+		}
+	}
+}
+
+func TestUpdateVideo_SendsPOSTToVideoPath(t *testing.T) {
+	var gotMethod, gotPath string
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+	}, http.StatusOK)
+	defer srv.Close()
+
+	c.UpdateVideo(context.Background(), "video-abc", VideoUpdate{Title: Ptr("New")})
+
+	if gotMethod != http.MethodPost {
+		t.Errorf("expected POST, got %q", gotMethod)
+	}
+	if want := "/library/123/videos/video-abc"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+}
+
+func TestUpdateVideo_OnlySetFieldsAreSent(t *testing.T) {
+	var body map[string]json.RawMessage
+	c, srv := inspectServer(t, captureBody(t, &body), http.StatusOK)
+	defer srv.Close()
+
+	_, err := c.UpdateVideo(context.Background(), "video-abc", VideoUpdate{
+		Title:    Ptr("New title"),
+		MetaTags: &[]MetaTag{{Property: "sha256", Value: "abc"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(body) != 2 {
+		t.Errorf("expected exactly 2 fields, got %v", body)
+	}
+	if string(body["title"]) != `"New title"` {
+		t.Errorf("title = %s, want \"New title\"", body["title"])
+	}
+	if string(body["metaTags"]) != `[{"property":"sha256","value":"abc"}]` {
+		t.Errorf("metaTags = %s", body["metaTags"])
+	}
+	for _, key := range []string{"collectionId", "chapters", "moments"} {
+		if _, ok := body[key]; ok {
+			t.Errorf("unset field %q was sent", key)
+		}
+	}
+}
+
+func TestUpdateVideo_EmptySliceClearsField(t *testing.T) {
+	var body map[string]json.RawMessage
+	c, srv := inspectServer(t, captureBody(t, &body), http.StatusOK)
+	defer srv.Close()
+
+	c.UpdateVideo(context.Background(), "video-abc", VideoUpdate{Chapters: &[]Chapter{}})
+
+	if string(body["chapters"]) != `[]` {
+		t.Errorf("chapters = %s, want []", body["chapters"])
+	}
+}
+
+func TestUpdateVideo_EmptyCollectionIDIsSent(t *testing.T) {
+	var body map[string]json.RawMessage
+	c, srv := inspectServer(t, captureBody(t, &body), http.StatusOK)
+	defer srv.Close()
+
+	c.UpdateVideo(context.Background(), "video-abc", VideoUpdate{CollectionID: Ptr("")})
+
+	if string(body["collectionId"]) != `""` {
+		t.Errorf("collectionId = %s, want \"\"", body["collectionId"])
+	}
+}
+
+func TestUpdateVideo_ValidationShortCircuits(t *testing.T) {
+	tests := []struct {
+		name    string
+		videoID string
+		update  VideoUpdate
+		wantErr error
+	}{
+		{"empty video id", "", VideoUpdate{Title: Ptr("x")}, ErrVideoIDRequired},
+		{"blank title", "video-abc", VideoUpdate{Title: Ptr("  ")}, ErrTitleRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			c, srv := inspectServer(t, func(r *http.Request) {
+				called = true
+			}, http.StatusOK)
+			defer srv.Close()
+
+			_, err := c.UpdateVideo(context.Background(), tt.videoID, tt.update)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if called {
+				t.Error("HTTP request was made despite invalid input")
+			}
+		})
+	}
+}
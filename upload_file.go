@@ -0,0 +1,191 @@
+package bunnystream
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// ErrUnsupportedVideoFormat is returned by UploadFile when the file does not
+// start like an MP4, MOV, MKV, WebM, AVI or MPEG-TS video. Use
+// SkipFormatCheck to upload other formats Bunny can transcode.
+var ErrUnsupportedVideoFormat = errors.New("file is not a supported video format")
+
+// sniffLen is the number of leading bytes inspected to detect the format.
+const sniffLen = 512
+
+// WithSHA256 computes the SHA-256 of the uploaded bytes while they stream and
+// passes its hex encoding to fn once UploadVideo or UploadFile succeeds, for
+// example to store it as a MetaTag with UpdateVideo for deduplication.
+// It is ignored by UploadVideoResumable.
+func WithSHA256(fn func(sum string)) UploadVideoOption {
+	return func(o *UploadVideoOptions) {
+		o.onSHA256 = fn
+	}
+}
+
+// SkipFormatCheck disables the video format detection of UploadFile.
+func SkipFormatCheck() UploadVideoOption {
+	return func(o *UploadVideoOptions) {
+		o.skipFormatCheck = true
+	}
+}
+
+// UploadFile uploads the video file at path, setting the Content-Length from
+// its size.
+//
+// Before any data is sent, the first bytes of the file are checked to reject
+// files that are obviously not videos with ErrUnsupportedVideoFormat. Accepts
+// every UploadVideoOption supported by UploadVideo.
+func (c *Client) UploadFile(ctx context.Context, videoID, path string, opts ...UploadVideoOption) (*Response, error) {
+	if strings.TrimSpace(videoID) == "" {
+		return nil, ErrVideoIDRequired
+	}
+
+	options := &UploadVideoOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open video file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat video file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%w: %s is a directory", ErrUnsupportedVideoFormat, path)
+	}
+
+	if !options.skipFormatCheck {
+		header := make([]byte, sniffLen)
+		n, err := io.ReadFull(f, header)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read video file: %w", err)
+		}
+
+		if _, ok := sniffVideoFormat(header[:n]); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedVideoFormat, path)
+		}
+
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to rewind video file: %w", err)
+		}
+	}
+
+	return c.UploadVideo(ctx, videoID, f, opts...)
+}
+
+// sniffVideoFormat detects the container format from the leading bytes of a
+// file and returns its MIME type.
+func sniffVideoFormat(header []byte) (string, bool) {
+	switch {
+	// ISO base media (MP4, MOV): a box size followed by a known box type.
+	case len(header) >= 8 && bytes.Equal(header[4:8], []byte("ftyp")):
+		if bytes.HasPrefix(header[8:], []byte("qt  ")) {
+			return "video/quicktime", true
+		}
+		return "video/mp4", true
+	case len(header) >= 8 && isQuickTimeAtom(header[4:8]):
+		return "video/quicktime", true
+
+	// EBML (Matroska, WebM): the DocType tells them apart.
+	case bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		if bytes.Contains(header, []byte("webm")) {
+			return "video/webm", true
+		}
+		return "video/x-matroska", true
+
+	// AVI: a RIFF container of type "AVI ".
+	case len(header) >= 12 && bytes.Equal(header[0:4], []byte("RIFF")) && bytes.Equal(header[8:12], []byte("AVI ")):
+		return "video/x-msvideo", true
+
+	// MPEG-TS: 188-byte packets starting with the 0x47 sync byte.
+	case isTransportStream(header, 188, 0), isTransportStream(header, 192, 4):
+		return "video/mp2t", true
+	}
+
+	return "", false
+}
+
+// isQuickTimeAtom reports whether typ is a top-level atom older QuickTime
+// files may start with instead of "ftyp".
+func isQuickTimeAtom(typ []byte) bool {
+	switch string(typ) {
+	case "moov", "mdat", "wide", "free", "skip", "pnot":
+		return true
+	}
+	return false
+}
+
+// isTransportStream reports whether header looks like an MPEG transport
+// stream with packets of the given size and sync byte position.
+func isTransportStream(header []byte, packetSize, syncOffset int) bool {
+	if len(header) <= syncOffset || header[syncOffset] != 0x47 {
+		return false
+	}
+	if next := packetSize + syncOffset; len(header) > next {
+		return header[next] == 0x47
+	}
+	return false
+}
+
+// bodyHasher computes a digest of a request body as it is sent, restarting
+// whenever the body is recreated for a retry.
+type bodyHasher struct {
+	h hash.Hash
+}
+
+// newBodyHasher returns a SHA-256 bodyHasher, or nil when fn is nil.
+func newBodyHasher(fn func(string)) *bodyHasher {
+	if fn == nil {
+		return nil
+	}
+	return &bodyHasher{h: sha256.New()}
+}
+
+// trackRequest wraps the body of req, and any body recreated for a retry, so
+// that reading it feeds the hash.
+func (b *bodyHasher) trackRequest(req *http.Request) {
+	if b == nil || req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+
+	req.Body = b.wrap(req.Body)
+
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			b.h.Reset()
+			return b.wrap(body), nil
+		}
+	}
+}
+
+// wrap returns body copying every byte read into the hash.
+func (b *bodyHasher) wrap(body io.ReadCloser) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.TeeReader(body, b.h), body}
+}
+
+// sum returns the hex digest of the bytes hashed so far.
+func (b *bodyHasher) sum() string {
+	return hex.EncodeToString(b.h.Sum(nil))
+}
@@ -0,0 +1,191 @@
+package bunnystream
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mp4Header is the start of a typical MP4 file.
+var mp4Header = []byte("\x00\x00\x00\x20ftypisom\x00\x00\x02\x00isomiso2avc1mp41")
+
+// writeTempFile writes data to a file in a temporary directory and returns
+// its path.
+func writeTempFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	return path
+}
+
+// -----------------------------------------------------------------------------
+// UploadFile
+// -----------------------------------------------------------------------------
+
+func TestUploadFile_SendsContentAndLength(t *testing.T) {
+	data := append(append([]byte(nil), mp4Header...), payload(1000)...)
+	path := writeTempFile(t, "video.mp4", data)
+
+	var gotLength int64
+	var gotBody []byte
+	c, srv := inspectServer(t, func(r *http.Request) {
+		gotLength = r.ContentLength
+		gotBody, _ = io.ReadAll(r.Body)
+	}, http.StatusOK)
+	defer srv.Close()
+
+	if _, err := c.UploadFile(context.Background(), "video-abc", path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotLength != int64(len(data)) {
+		t.Errorf("Content-Length = %d, want %d", gotLength, len(data))
+	}
+	if !bytes.Equal(gotBody, data) {
+		t.Error("uploaded body does not match the file content")
+	}
+}
+
+func TestUploadFile_RejectsNonVideoBeforeHTTP(t *testing.T) {
+	path := writeTempFile(t, "notes.txt", []byte("definitely not a video"))
+
+	called := false
+	c, srv := inspectServer(t, func(r *http.Request) {
+		called = true
+	}, http.StatusOK)
+	defer srv.Close()
+
+	_, err := c.UploadFile(context.Background(), "video-abc", path)
+
+	if !errors.Is(err, ErrUnsupportedVideoFormat) {
+		t.Errorf("expected ErrUnsupportedVideoFormat, got %v", err)
+	}
+	if called {
+		t.Error("HTTP request was made for a non-video file")
+	}
+}
+
+func TestUploadFile_SkipFormatCheck(t *testing.T) {
+	path := writeTempFile(t, "video.flv", []byte("FLV\x01 not sniffed"))
+
+	c, srv := inspectServer(t, func(r *http.Request) {}, http.StatusOK)
+	defer srv.Close()
+
+	if _, err := c.UploadFile(context.Background(), "video-abc", path, SkipFormatCheck()); err != nil {
+		t.Errorf("expected upload to proceed with SkipFormatCheck, got %v", err)
+	}
+}
+
+func TestUploadFile_MissingFile(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+
+	_, err := c.UploadFile(context.Background(), "video-abc", filepath.Join(t.TempDir(), "missing.mp4"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+}
+
+func TestUploadFile_Directory(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+
+	_, err := c.UploadFile(context.Background(), "video-abc", t.TempDir())
+	if !errors.Is(err, ErrUnsupportedVideoFormat) {
+		t.Errorf("expected ErrUnsupportedVideoFormat, got %v", err)
+	}
+}
+
+func TestUploadFile_EmptyVideoID(t *testing.T) {
+	c := mustNewClient(t, baseConfig())
+
+	if _, err := c.UploadFile(context.Background(), " ", "video.mp4"); !errors.Is(err, ErrVideoIDRequired) {
+		t.Errorf("expected ErrVideoIDRequired, got %v", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithSHA256
+// -----------------------------------------------------------------------------
+
+func TestWithSHA256_ReportsDigestOfUploadedBytes(t *testing.T) {
+	data := append(append([]byte(nil), mp4Header...), payload(5000)...)
+	path := writeTempFile(t, "video.mp4", data)
+
+	// The first attempt fails so the digest must survive a retry.
+	c, srv, _ := sequenceServer(t, []int{http.StatusServiceUnavailable, http.StatusOK}, nil)
+	defer srv.Close()
+
+	var got string
+	if _, err := c.UploadFile(context.Background(), "video-abc", path, WithSHA256(func(s string) { got = s })); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sum := sha256.Sum256(data)
+	if want := hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("sha256 = %q, want %q", got, want)
+	}
+}
+
+func TestWithSHA256_NotCalledOnFailure(t *testing.T) {
+	c, srv := testServer(t, http.StatusBadRequest, "")
+	defer srv.Close()
+
+	called := false
+	c.UploadVideo(context.Background(), "video-abc", strings.NewReader("data"), WithSHA256(func(string) { called = true }))
+
+	if called {
+		t.Error("WithSHA256 callback called for a failed upload")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// sniffVideoFormat
+// -----------------------------------------------------------------------------
+
+func TestSniffVideoFormat(t *testing.T) {
+	ts := make([]byte, 400)
+	ts[0], ts[188], ts[376] = 0x47, 0x47, 0x47
+
+	m2ts := make([]byte, 400)
+	m2ts[4], m2ts[196] = 0x47, 0x47
+
+	tests := []struct {
+		name     string
+		header   []byte
+		wantMIME string
+		wantOK   bool
+	}{
+		{"mp4", mp4Header, "video/mp4", true},
+		{"mov ftyp", []byte("\x00\x00\x00\x14ftypqt  \x00\x00\x00\x00"), "video/quicktime", true},
+		{"mov legacy", []byte("\x00\x00\x00\x08wide\x00\x00\x00\x00mdat"), "video/quicktime", true},
+		{"mkv", []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01\x42\x82\x88matroska"), "video/x-matroska", true},
+		{"webm", []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01\x42\x82\x84webm"), "video/webm", true},
+		{"avi", []byte("RIFF\x00\x00\x00\x00AVI LIST"), "video/x-msvideo", true},
+		{"wav is not avi", []byte("RIFF\x00\x00\x00\x00WAVEfmt "), "", false},
+		{"ts", ts, "video/mp2t", true},
+		{"m2ts", m2ts, "video/mp2t", true},
+		{"single sync byte", []byte{0x47, 0x00}, "", false},
+		{"text", []byte("hello world, this is text"), "", false},
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"), "", false},
+		{"empty", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mime, ok := sniffVideoFormat(tt.header)
+			if mime != tt.wantMIME || ok != tt.wantOK {
+				t.Errorf("sniffVideoFormat = (%q, %v), want (%q, %v)", mime, ok, tt.wantMIME, tt.wantOK)
+			}
+		})
+	}
+}
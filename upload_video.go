@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type Resolution string
@@ -36,6 +37,18 @@ type UploadVideoOptions struct {
 	genereateDesc       *bool
 	generateChapter     *bool
 	generateMoments     *bool
+	onProgress          func(UploadProgress)
+	onSHA256            func(sum string)
+	skipFormatCheck     bool
+	deleteOnFailure     bool
+
+	// Resumable (TUS) upload settings, ignored by UploadVideo.
+	chunkSize       int64
+	resumeURL       string
+	onUploadCreated func(uploadURL string)
+	uploadExpiry    time.Duration
+	uploadTitle     string
+	uploadFileType  string
 }
 
 type UploadVideoOption func(*UploadVideoOptions)
@@ -105,6 +118,56 @@ func GenerateMoments(v bool) UploadVideoOption {
 	}
 }
 
+// ChunkSize sets the number of bytes sent per PATCH request by
+// UploadVideoResumable. Smaller chunks lose less progress on failure.
+// Defaults to DefaultChunkSize.
+func ChunkSize(n int64) UploadVideoOption {
+	return func(o *UploadVideoOptions) {
+		o.chunkSize = n
+	}
+}
+
+// ResumeFrom continues a previous UploadVideoResumable session instead of
+// creating a new one. Pass the upload URL reported by OnUploadCreated.
+func ResumeFrom(uploadURL string) UploadVideoOption {
+	return func(o *UploadVideoOptions) {
+		o.resumeURL = uploadURL
+	}
+}
+
+// OnUploadCreated registers a callback that receives the upload URL of a new
+// UploadVideoResumable session. Persist it to resume the upload with
+// ResumeFrom after a crash or restart.
+func OnUploadCreated(fn func(uploadURL string)) UploadVideoOption {
+	return func(o *UploadVideoOptions) {
+		o.onUploadCreated = fn
+	}
+}
+
+// UploadExpiry sets how long the signature of an UploadVideoResumable session
+// stays valid. Defaults to DefaultUploadExpiry.
+func UploadExpiry(ttl time.Duration) UploadVideoOption {
+	return func(o *UploadVideoOptions) {
+		o.uploadExpiry = ttl
+	}
+}
+
+// UploadTitle sets the title sent in the metadata of an UploadVideoResumable
+// session.
+func UploadTitle(title string) UploadVideoOption {
+	return func(o *UploadVideoOptions) {
+		o.uploadTitle = title
+	}
+}
+
+// UploadFileType sets the MIME type sent in the metadata of an
+// UploadVideoResumable session, e.g. "video/mp4".
+func UploadFileType(fileType string) UploadVideoOption {
+	return func(o *UploadVideoOptions) {
+		o.uploadFileType = fileType
+	}
+}
+
 func FromVideoOption(v UploadVideoOptions) UploadVideoOption {
 	return func(o *UploadVideoOptions) {
 		*o = v
@@ -123,11 +186,21 @@ func (c *Client) UploadVideo(ctx context.Context, videoId string, videoFile io.R
 		opt(options)
 	}
 
+	var total int64 = -1
+	if options.onProgress != nil {
+		total = readerSize(videoFile)
+	}
+
 	req, err := c.request(ctx, http.MethodPut, uri, videoFile, "application/octet-stream")
 	if err != nil {
 		return nil, err
 	}
 
+	newProgressTracker(options.onProgress, total).trackRequest(req)
+
+	hasher := newBodyHasher(options.onSHA256)
+	hasher.trackRequest(req)
+
 	// query := req.URL.Query()
 
 	// if options.jitEnabled != nil {
@@ -188,5 +261,9 @@ func (c *Client) UploadVideo(ctx context.Context, videoId string, videoFile io.R
 		return nil, err
 	}
 
+	if hasher != nil {
+		options.onSHA256(hasher.sum())
+	}
+
 	return resp, nil
 }
@@ -0,0 +1,139 @@
+package bunnystream
+
+import "strings"
+
+// Video is a video object stored in a Bunny Stream library.
+type Video struct {
+	// VideoLibraryID is the ID of the library the video belongs to.
+	VideoLibraryID int64 `json:"videoLibraryId"`
+
+	// GUID is the unique ID of the video. This is the videoID expected by
+	// every other method of the client.
+	GUID string `json:"guid"`
+
+	// Title is the display name of the video.
+	Title string `json:"title"`
+
+	// DateUploaded is the ISO 8601 date (UTC) the video object was created.
+	DateUploaded string `json:"dateUploaded"`
+
+	// Views is the number of times the video has been watched.
+	Views int64 `json:"views"`
+
+	// IsPublic reports whether the video is publicly accessible.
+	IsPublic bool `json:"isPublic"`
+
+	// Length is the duration of the video in seconds.
+	Length int `json:"length"`
+
+	// Status is the processing status of the video.
+	Status VideoStatus `json:"status"`
+
+	// Framerate is the framerate of the source video.
+	Framerate float64 `json:"framerate"`
+
+	// Width is the width of the source video in pixels.
+	Width int `json:"width"`
+
+	// Height is the height of the source video in pixels.
+	Height int `json:"height"`
+
+	// AvailableResolutions is the comma-separated list of encoded
+	// resolutions, e.g. "360p,720p". Use Resolutions for a parsed slice.
+	AvailableResolutions string `json:"availableResolutions"`
+
+	// ThumbnailCount is the number of thumbnails generated for the video.
+	ThumbnailCount int `json:"thumbnailCount"`
+
+	// EncodeProgress is the encoding progress of the video, from 0 to 100.
+	EncodeProgress int `json:"encodeProgress"`
+
+	// StorageSize is the total storage used by the video and its
+	// encodings, in bytes.
+	StorageSize int64 `json:"storageSize"`
+
+	// Captions lists the caption tracks attached to the video.
+	Captions []Caption `json:"captions"`
+
+	// HasMP4Fallback reports whether MP4 fallback files were generated.
+	HasMP4Fallback bool `json:"hasMP4Fallback"`
+
+	// CollectionID is the ID of the collection the video belongs to, if any.
+	CollectionID string `json:"collectionId"`
+
+	// ThumbnailFileName is the file name of the video thumbnail.
+	ThumbnailFileName string `json:"thumbnailFileName"`
+
+	// AverageWatchTime is the average watch time of the video in seconds.
+	AverageWatchTime int64 `json:"averageWatchTime"`
+
+	// TotalWatchTime is the total watch time of the video in seconds.
+	TotalWatchTime int64 `json:"totalWatchTime"`
+
+	// Category is the automatically detected category of the video.
+	Category string `json:"category"`
+
+	// Chapters lists the chapters of the video.
+	Chapters []Chapter `json:"chapters"`
+
+	// Moments lists the moments of the video.
+	Moments []Moment `json:"moments"`
+
+	// MetaTags lists the custom meta tags of the video.
+	MetaTags []MetaTag `json:"metaTags"`
+}
+
+// Resolutions returns the parsed list of encoded resolutions of the video.
+func (v *Video) Resolutions() []Resolution {
+	if strings.TrimSpace(v.AvailableResolutions) == "" {
+		return nil
+	}
+
+	parts := strings.Split(v.AvailableResolutions, ",")
+	resolutions := make([]Resolution, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			resolutions = append(resolutions, Resolution(p))
+		}
+	}
+	return resolutions
+}
+
+// Caption is a caption track attached to a video.
+type Caption struct {
+	// SrcLang is the language code of the caption, e.g. "en".
+	SrcLang string `json:"srclang"`
+
+	// Label is the display label of the caption.
+	Label string `json:"label"`
+}
+
+// Chapter is a titled section of a video.
+type Chapter struct {
+	// Title is the display title of the chapter.
+	Title string `json:"title"`
+
+	// Start is the start of the chapter in seconds.
+	Start int `json:"start"`
+
+	// End is the end of the chapter in seconds.
+	End int `json:"end"`
+}
+
+// Moment is a labelled point in time within a video.
+type Moment struct {
+	// Label is the display label of the moment.
+	Label string `json:"label"`
+
+	// Timestamp is the position of the moment in seconds.
+	Timestamp int `json:"timestamp"`
+}
+
+// MetaTag is a custom key/value pair stored on a video.
+type MetaTag struct {
+	// Property is the name of the meta tag.
+	Property string `json:"property"`
+
+	// Value is the value of the meta tag.
+	Value string `json:"value"`
+}
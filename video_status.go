@@ -0,0 +1,102 @@
+package bunnystream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VideoStatus is the processing status of a video as reported by Bunny.
+//
+// It decodes from the numeric codes used by the API, as well as from quoted
+// codes and status names, and encodes back to the numeric code. Webhooks use
+// a different set of codes: decode their status with webhook.Status instead.
+type VideoStatus int
+
+// Video statuses returned by the API.
+const (
+	StatusCreated             VideoStatus = 0
+	StatusUploaded            VideoStatus = 1
+	StatusProcessing          VideoStatus = 2
+	StatusTranscoding         VideoStatus = 3
+	StatusFinished            VideoStatus = 4
+	StatusError               VideoStatus = 5
+	StatusUploadFailed        VideoStatus = 6
+	StatusJitSegmenting       VideoStatus = 7
+	StatusJitPlaylistsCreated VideoStatus = 8
+)
+
+// videoStatusNames maps each known status to its name.
+var videoStatusNames = map[VideoStatus]string{
+	StatusCreated:             "Created",
+	StatusUploaded:            "Uploaded",
+	StatusProcessing:          "Processing",
+	StatusTranscoding:         "Transcoding",
+	StatusFinished:            "Finished",
+	StatusError:               "Error",
+	StatusUploadFailed:        "UploadFailed",
+	StatusJitSegmenting:       "JitSegmenting",
+	StatusJitPlaylistsCreated: "JitPlaylistsCreated",
+}
+
+// String returns the name of the status, e.g. "Finished".
+func (s VideoStatus) String() string {
+	if name, ok := videoStatusNames[s]; ok {
+		return name
+	}
+	return "VideoStatus(" + strconv.Itoa(int(s)) + ")"
+}
+
+// IsTerminal reports whether the video will not change status anymore
+// without a new upload: it either finished or failed.
+func (s VideoStatus) IsTerminal() bool {
+	return s == StatusFinished || s.IsFailed()
+}
+
+// IsPlayable reports whether the video can be streamed, either fully encoded
+// or through JIT playlists.
+func (s VideoStatus) IsPlayable() bool {
+	return s == StatusFinished || s == StatusJitPlaylistsCreated
+}
+
+// IsFailed reports whether the video failed to encode or upload.
+func (s VideoStatus) IsFailed() bool {
+	return s == StatusError || s == StatusUploadFailed
+}
+
+// MarshalJSON encodes the status as its numeric code.
+func (s VideoStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+// UnmarshalJSON decodes a numeric code, a quoted numeric code, or a status
+// name (case-insensitive).
+func (s *VideoStatus) UnmarshalJSON(data []byte) error {
+	text := string(bytes.TrimSpace(data))
+	if text == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		text = strings.TrimSpace(text)
+	}
+
+	if code, err := strconv.Atoi(text); err == nil {
+		*s = VideoStatus(code)
+		return nil
+	}
+
+	for status, name := range videoStatusNames {
+		if strings.EqualFold(name, text) {
+			*s = status
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid video status %q", text)
+}
@@ -0,0 +1,124 @@
+package bunnystream
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVideoStatus_String(t *testing.T) {
+	tests := []struct {
+		status VideoStatus
+		want   string
+	}{
+		{StatusCreated, "Created"},
+		{StatusFinished, "Finished"},
+		{StatusUploadFailed, "UploadFailed"},
+		{StatusJitPlaylistsCreated, "JitPlaylistsCreated"},
+		{VideoStatus(42), "VideoStatus(42)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.String(); got != tt.want {
+			t.Errorf("VideoStatus(%d).String() = %q, want %q", int(tt.status), got, tt.want)
+		}
+	}
+}
+
+func TestVideoStatus_Predicates(t *testing.T) {
+	tests := []struct {
+		status                     VideoStatus
+		terminal, playable, failed bool
+	}{
+		{StatusCreated, false, false, false},
+		{StatusUploaded, false, false, false},
+		{StatusProcessing, false, false, false},
+		{StatusTranscoding, false, false, false},
+		{StatusFinished, true, true, false},
+		{StatusError, true, false, true},
+		{StatusUploadFailed, true, false, true},
+		{StatusJitSegmenting, false, false, false},
+		{StatusJitPlaylistsCreated, false, true, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.IsTerminal(); got != tt.terminal {
+			t.Errorf("%s.IsTerminal() = %v, want %v", tt.status, got, tt.terminal)
+		}
+		if got := tt.status.IsPlayable(); got != tt.playable {
+			t.Errorf("%s.IsPlayable() = %v, want %v", tt.status, got, tt.playable)
+		}
+		if got := tt.status.IsFailed(); got != tt.failed {
+			t.Errorf("%s.IsFailed() = %v, want %v", tt.status, got, tt.failed)
+		}
+	}
+}
+
+func TestVideoStatus_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(struct {
+		Status VideoStatus `json:"status"`
+	}{StatusTranscoding})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(b) != `{"status":3}` {
+		t.Errorf("JSON = %s, want {\"status\":3}", b)
+	}
+}
+
+func TestVideoStatus_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    VideoStatus
+		wantErr bool
+	}{
+		{`4`, StatusFinished, false},
+		{`"4"`, StatusFinished, false},
+		{`"Finished"`, StatusFinished, false},
+		{`"jitplaylistscreated"`, StatusJitPlaylistsCreated, false},
+		{`12`, VideoStatus(12), false},
+		{`"nope"`, 0, true},
+		{`true`, 0, true},
+	}
+
+	for _, tt := range tests {
+		var got VideoStatus
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestVideoStatus_NullLeavesValue(t *testing.T) {
+	got := StatusProcessing
+	if err := json.Unmarshal([]byte(`null`), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != StatusProcessing {
+		t.Errorf("status = %v, want unchanged Processing", got)
+	}
+}
+
+func TestVideoStatus_DecodesInVideoAndWebhookShapes(t *testing.T) {
+	var video Video
+	if err := json.Unmarshal([]byte(`{"guid":"a","status":4}`), &video); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !video.Status.IsPlayable() {
+		t.Errorf("video status = %v, want playable", video.Status)
+	}
+
+	var payload struct {
+		Status VideoStatus `json:"Status"`
+	}
+	if err := json.Unmarshal([]byte(`{"VideoLibraryId":123,"VideoGuid":"a","Status":5}`), &payload); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payload.Status != StatusError {
+		t.Errorf("webhook status = %v, want Error", payload.Status)
+	}
+}
@@ -0,0 +1,118 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Defaults for WaitOptions.
+const (
+	DefaultWaitInterval    time.Duration = 5 * time.Second
+	DefaultWaitMaxInterval time.Duration = time.Minute
+)
+
+// ErrEncodingFailed is matched by errors.Is for every *EncodingError.
+var ErrEncodingFailed = errors.New("video encoding failed")
+
+// EncodingError is returned by WaitForEncoding when a video ends in a failed
+// state.
+type EncodingError struct {
+	// VideoID is the ID of the video that failed.
+	VideoID string
+
+	// Status is the failed status, StatusError or StatusUploadFailed.
+	Status VideoStatus
+}
+
+// Error implements the error interface.
+func (e *EncodingError) Error() string {
+	return fmt.Sprintf("video %s encoding failed (status %s)", e.VideoID, e.Status)
+}
+
+// Unwrap lets errors.Is match ErrEncodingFailed.
+func (e *EncodingError) Unwrap() error {
+	return ErrEncodingFailed
+}
+
+// WaitOptions configures WaitForEncoding. Zero values fall back to defaults.
+type WaitOptions struct {
+	// Interval is the delay between the first polls, and the delay polling
+	// returns to whenever progress is made. Defaults to DefaultWaitInterval.
+	Interval time.Duration
+
+	// MaxInterval caps the delay between polls, which grows while the video
+	// makes no progress. Defaults to DefaultWaitMaxInterval.
+	MaxInterval time.Duration
+
+	// UntilPlayable returns as soon as JIT playlists are created, instead of
+	// waiting for every resolution to finish encoding.
+	UntilPlayable bool
+
+	// OnProgress is called with the latest video whenever its status or
+	// encode progress changes.
+	OnProgress func(*Video)
+}
+
+// WaitForEncoding polls a video until it finishes processing and returns its
+// final state.
+//
+// Polling backs off while the status and encode progress stay unchanged and
+// speeds up again when they move. If the video fails to encode or upload,
+// an *EncodingError matching ErrEncodingFailed is returned with the video.
+// Waiting stops when ctx is done.
+func (c *Client) WaitForEncoding(ctx context.Context, videoID string, opts WaitOptions) (*Video, error) {
+	if strings.TrimSpace(videoID) == "" {
+		return nil, ErrVideoIDRequired
+	}
+
+	interval := opts.Interval
+	if interval < 1 {
+		interval = DefaultWaitInterval
+	}
+
+	maxInterval := opts.MaxInterval
+	if maxInterval < 1 {
+		maxInterval = DefaultWaitMaxInterval
+	}
+	maxInterval = max(maxInterval, interval)
+
+	var (
+		last  *Video
+		delay = interval
+	)
+
+	for {
+		video, _, err := c.GetVideo(ctx, videoID)
+		if err != nil {
+			return last, err
+		}
+
+		changed := last == nil || video.Status != last.Status || video.EncodeProgress != last.EncodeProgress
+		if changed && opts.OnProgress != nil {
+			opts.OnProgress(video)
+		}
+		last = video
+
+		switch {
+		case video.Status.IsFailed():
+			return video, &EncodingError{VideoID: videoID, Status: video.Status}
+		case video.Status == StatusFinished:
+			return video, nil
+		case opts.UntilPlayable && video.Status == StatusJitPlaylistsCreated:
+			return video, nil
+		}
+
+		if changed {
+			delay = interval
+		} else {
+			delay = min(delay*3/2, maxInterval)
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return video, err
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package bunnystream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// encodingServer serves GetVideo responses walking through the given
+// status/progress steps, one per poll, repeating the last one. Returns a
+// client and a counter of polls.
+func encodingServer(t *testing.T, steps [][2]int) (*Client, func(), *atomic.Int32) {
+	t.Helper()
+
+	var polls atomic.Int32
+	c, srv := handlerServer(t, func(w http.ResponseWriter, r *http.Request) {
+		n := int(polls.Add(1)) - 1
+		step := steps[min(n, len(steps)-1)]
+		fmt.Fprintf(w, `{"guid":"video-abc","status":%d,"encodeProgress":%d}`, step[0], step[1])
+	})
+
+	return c, srv.Close, &polls
+}
+
+func TestWaitForEncoding_ReturnsWhenFinished(t *testing.T) {
+	c, closeSrv, polls := encodingServer(t, [][2]int{
+		{int(StatusUploaded), 0},
+		{int(StatusProcessing), 0},
+		{int(StatusTranscoding), 40},
+		{int(StatusFinished), 100},
+	})
+	defer closeSrv()
+
+	var seen []VideoStatus
+	video, err := c.WaitForEncoding(context.Background(), "video-abc", WaitOptions{
+		Interval:   time.Millisecond,
+		OnProgress: func(v *Video) { seen = append(seen, v.Status) },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if video.Status != StatusFinished || video.EncodeProgress != 100 {
+		t.Errorf("final video = %+v", video)
+	}
+	if polls.Load() != 4 {
+		t.Errorf("polls = %d, want 4", polls.Load())
+	}
+	want := []VideoStatus{StatusUploaded, StatusProcessing, StatusTranscoding, StatusFinished}
+	if fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Errorf("OnProgress statuses = %v, want %v", seen, want)
+	}
+}
+
+func TestWaitForEncoding_OnProgressOnlyOnChange(t *testing.T) {
+	c, closeSrv, _ := encodingServer(t, [][2]int{
+		{int(StatusTranscoding), 10},
+		{int(StatusTranscoding), 10},
+		{int(StatusTranscoding), 10},
+		{int(StatusTranscoding), 60},
+		{int(StatusFinished), 100},
+	})
+	defer closeSrv()
+
+	var progress []int
+	c.WaitForEncoding(context.Background(), "video-abc", WaitOptions{
+		Interval:   time.Millisecond,
+		OnProgress: func(v *Video) { progress = append(progress, v.EncodeProgress) },
+	})
+
+	if fmt.Sprint(progress) != "[10 60 100]" {
+		t.Errorf("OnProgress values = %v, want [10 60 100]", progress)
+	}
+}
+
+func TestWaitForEncoding_FailedStatusesReturnEncodingError(t *testing.T) {
+	for _, status := range []VideoStatus{StatusError, StatusUploadFailed} {
+		c, closeSrv, _ := encodingServer(t, [][2]int{{int(StatusProcessing), 0}, {int(status), 0}})
+
+		video, err := c.WaitForEncoding(context.Background(), "video-abc", WaitOptions{Interval: time.Millisecond})
+
+		if !errors.Is(err, ErrEncodingFailed) {
+			t.Errorf("status %d: expected ErrEncodingFailed, got %v", status, err)
+		}
+		var encErr *EncodingError
+		if !errors.As(err, &encErr) || encErr.Status != status || encErr.VideoID != "video-abc" {
+			t.Errorf("status %d: EncodingError = %+v", status, encErr)
+		}
+		if video == nil || video.Status != status {
+			t.Errorf("status %d: expected the failed video to be returned, got %+v", status, video)
+		}
+		closeSrv()
+	}
+}
+
+func TestWaitForEncoding_UntilPlayable(t *testing.T) {
+	steps := [][2]int{{int(StatusJitSegmenting), 0}, {int(StatusJitPlaylistsCreated), 20}, {int(StatusFinished), 100}}
+
+	c, closeSrv, polls := encodingServer(t, steps)
+	defer closeSrv()
+
+	video, err := c.WaitForEncoding(context.Background(), "video-abc", WaitOptions{Interval: time.Millisecond, UntilPlayable: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if video.Status != StatusJitPlaylistsCreated || polls.Load() != 2 {
+		t.Errorf("status = %d after %d polls, want JitPlaylistsCreated after 2", video.Status, polls.Load())
+	}
+
+	c2, closeSrv2, _ := encodingServer(t, steps)
+	defer closeSrv2()
+
+	video, _ = c2.WaitForEncoding(context.Background(), "video-abc", WaitOptions{Interval: time.Millisecond})
+	if video.Status != StatusFinished {
+		t.Errorf("without UntilPlayable status = %d, want Finished", video.Status)
+	}
+}
+
+func TestWaitForEncoding_ContextCancelled(t *testing.T) {
+	c, closeSrv, _ := encodingServer(t, [][2]int{{int(StatusProcessing), 0}})
+	defer closeSrv()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	video, err := c.WaitForEncoding(ctx, "video-abc", WaitOptions{Interval: 5 * time.Millisecond})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if video == nil || video.Status != StatusProcessing {
+		t.Errorf("expected last known video, got %+v", video)
+	}
+}
+
+func TestWaitForEncoding_BacksOffWithoutProgress(t *testing.T) {
+	c, closeSrv, polls := encodingServer(t, [][2]int{{int(StatusProcessing), 0}})
+	defer closeSrv()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	c.WaitForEncoding(ctx, "video-abc", WaitOptions{Interval: 10 * time.Millisecond, MaxInterval: time.Second})
+
+	// Fixed 10ms polling would take ~20 polls; growing by 1.5x takes ~7.
+	if n := polls.Load(); n > 10 {
+		t.Errorf("polls = %d, expected backoff to reduce polling", n)
+	}
+}
+
+func TestWaitForEncoding_PropagatesAPIErrors(t *testing.T) {
+	c, srv := testServer(t, http.StatusNotFound, "")
+	defer srv.Close()
+
+	if _, err := c.WaitForEncoding(context.Background(), "video-abc", WaitOptions{}); !errors.Is(err, ErrVideoNotFound) {
+		t.Errorf("expected ErrVideoNotFound, got %v", err)
+	}
+	if _, err := c.WaitForEncoding(context.Background(), "", WaitOptions{}); !errors.Is(err, ErrVideoIDRequired) {
+		t.Errorf("expected ErrVideoIDRequired, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package webhook
+
+import (
+	"context"
+	"strconv"
+)
+
+// DefaultStoreSize is the number of entries kept by NewMemoryStore and the
+// ordering guard when no size is given.
+const DefaultStoreSize = 10000
+
+// Store records the webhook events a Handler has already processed, so that
+// redeliveries are acknowledged without running the callbacks again.
+//
+// Keys have the form "<library>/<guid>/<status>". Implementations backed by a
+// shared database or cache let several replicas deduplicate together.
+type Store interface {
+	// Add records key and reports whether it was not recorded before.
+	Add(ctx context.Context, key string) (bool, error)
+
+	// Remove forgets key, so that a redelivery is processed again. The
+	// Handler calls it when a callback fails.
+	Remove(ctx context.Context, key string) error
+}
+
+// MemoryStore is an in-process Store keeping the most recently seen keys.
+type MemoryStore struct {
+	keys *lru[string, struct{}]
+}
+
+// NewMemoryStore returns a Store remembering up to size keys, evicting the
+// least recently seen ones first. A size <= 0 uses DefaultStoreSize.
+func NewMemoryStore(size int) *MemoryStore {
+	if size <= 0 {
+		size = DefaultStoreSize
+	}
+	return &MemoryStore{keys: newLRU[string, struct{}](size)}
+}
+
+// Add implements Store.
+func (s *MemoryStore) Add(_ context.Context, key string) (bool, error) {
+	added := false
+	s.keys.update(key, func(_ struct{}, ok bool) (struct{}, bool) {
+		added = !ok
+		return struct{}{}, added
+	})
+	return added, nil
+}
+
+// Remove implements Store.
+func (s *MemoryStore) Remove(_ context.Context, key string) error {
+	s.keys.remove(key)
+	return nil
+}
+
+// WithDedupe makes the Handler acknowledge redelivered events without
+// dispatching them again. A nil store uses NewMemoryStore(DefaultStoreSize).
+func WithDedupe(store Store) Option {
+	return func(o *options) {
+		if store == nil {
+			store = NewMemoryStore(DefaultStoreSize)
+		}
+		o.store = store
+	}
+}
+
+// WithOrderingGuard makes the Handler drop events that would move a video
+// back in its lifecycle, such as Encoding arriving after Finished. It
+// remembers the state of up to size videos (DefaultStoreSize when size <= 0).
+//
+// CaptionsGenerated and TitleOrDescriptionGenerated are not lifecycle steps
+// and always pass. A video that is re-encoded after finishing restarts at
+// Queued, which the guard drops until the video is evicted.
+func WithOrderingGuard(size int) Option {
+	return func(o *options) {
+		if size <= 0 {
+			size = DefaultStoreSize
+		}
+		o.guardSize = size
+	}
+}
+
+// eventKey returns the dedupe key of event.
+func eventKey(event Event) string {
+	return videoKey(event) + "/" + strconv.Itoa(int(event.Status))
+}
+
+// videoKey identifies the video of event.
+func videoKey(event Event) string {
+	return strconv.FormatInt(event.VideoLibraryID, 10) + "/" + event.VideoGUID
+}
+
+// lifecycleRank orders the statuses of a video's lifecycle. Statuses that
+// are not lifecycle steps are absent.
+var lifecycleRank = map[Status]int{
+	StatusPresignedUploadStarted:  0,
+	StatusPresignedUploadFinished: 1,
+	StatusPresignedUploadFailed:   1,
+	StatusQueued:                  2,
+	StatusProcessing:              3,
+	StatusEncoding:                4,
+	StatusResolutionFinished:      5,
+	StatusFinished:                6,
+	StatusFailed:                  6,
+}
+
+// statusGuard tracks the furthest lifecycle step seen per video.
+type statusGuard struct {
+	ranks *lru[string, int]
+}
+
+func newStatusGuard(size int) *statusGuard {
+	return &statusGuard{ranks: newLRU[string, int](size)}
+}
+
+// allows reports whether event does not regress its video's known state.
+func (g *statusGuard) allows(event Event) bool {
+	rank, ok := lifecycleRank[event.Status]
+	if !ok {
+		return true
+	}
+
+	allowed := true
+	g.ranks.update(videoKey(event), func(current int, known bool) (int, bool) {
+		allowed = !known || rank >= current
+		return 0, false
+	})
+	return allowed
+}
+
+// advance records event as the latest state of its video.
+func (g *statusGuard) advance(event Event) {
+	rank, ok := lifecycleRank[event.Status]
+	if !ok {
+		return
+	}
+
+	g.ranks.update(videoKey(event), func(current int, known bool) (int, bool) {
+		return rank, !known || rank > current
+	})
+}
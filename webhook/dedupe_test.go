@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// eventBody returns a webhook payload for library 123.
+func eventBody(guid string, status Status) string {
+	return fmt.Sprintf(`{"VideoLibraryId":123,"VideoGuid":%q,"Status":%d}`, guid, status)
+}
+
+// countingHandler returns a handler counting the events dispatched per status.
+func countingHandler(t *testing.T, opts ...Option) (*Handler, map[Status]int) {
+	t.Helper()
+	h := mustNewHandler(t, opts...)
+	counts := make(map[Status]int)
+	h.OnEvent(func(_ context.Context, e Event) error {
+		counts[e.Status]++
+		return nil
+	})
+	return h, counts
+}
+
+// -----------------------------------------------------------------------------
+// MemoryStore
+// -----------------------------------------------------------------------------
+
+func TestMemoryStore_AddAndRemove(t *testing.T) {
+	s := NewMemoryStore(0)
+	ctx := context.Background()
+
+	if added, _ := s.Add(ctx, "k"); !added {
+		t.Error("first Add should report added")
+	}
+	if added, _ := s.Add(ctx, "k"); added {
+		t.Error("second Add should report duplicate")
+	}
+	if err := s.Remove(ctx, "k"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if added, _ := s.Add(ctx, "k"); !added {
+		t.Error("Add after Remove should report added")
+	}
+}
+
+func TestMemoryStore_EvictsLeastRecentlySeen(t *testing.T) {
+	s := NewMemoryStore(2)
+	ctx := context.Background()
+
+	s.Add(ctx, "a")
+	s.Add(ctx, "b")
+	s.Add(ctx, "a") // touch a, so b is the oldest
+	s.Add(ctx, "c")
+
+	if n := s.keys.len(); n != 2 {
+		t.Errorf("len = %d, want 2", n)
+	}
+	if added, _ := s.Add(ctx, "a"); added {
+		t.Error("a should still be remembered")
+	}
+	if added, _ := s.Add(ctx, "b"); !added {
+		t.Error("b should have been evicted")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithDedupe
+// -----------------------------------------------------------------------------
+
+func TestHandler_DedupeDropsRedelivery(t *testing.T) {
+	h, counts := countingHandler(t, WithDedupe(nil))
+
+	for range 3 {
+		if code := serve(h, newRequest(eventBody("video-abc", StatusFinished))); code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", code)
+		}
+	}
+	serve(h, newRequest(eventBody("video-def", StatusFinished)))
+
+	if counts[StatusFinished] != 2 {
+		t.Errorf("Finished dispatched %d times, want 2", counts[StatusFinished])
+	}
+}
+
+func TestHandler_DedupeRetriesFailedCallback(t *testing.T) {
+	h := mustNewHandler(t, WithDedupe(nil))
+
+	calls := 0
+	h.OnFinished(func(context.Context, Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("smtp down")
+		}
+		return nil
+	})
+
+	body := eventBody("video-abc", StatusFinished)
+	if code := serve(h, newRequest(body)); code != http.StatusInternalServerError {
+		t.Fatalf("first delivery status = %d, want 500", code)
+	}
+	if code := serve(h, newRequest(body)); code != http.StatusOK {
+		t.Fatalf("redelivery status = %d, want 200", code)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Add(context.Context, string) (bool, error) {
+	return false, errors.New("redis down")
+}
+func (failingStore) Remove(context.Context, string) error { return nil }
+
+func TestHandler_DedupeStoreErrorReturns500(t *testing.T) {
+	h, counts := countingHandler(t, WithDedupe(failingStore{}))
+
+	if code := serve(h, newRequest(finishedPayload)); code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", code)
+	}
+	if len(counts) != 0 {
+		t.Errorf("dispatched %v, want nothing", counts)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithOrderingGuard
+// -----------------------------------------------------------------------------
+
+func TestHandler_OrderingGuardDropsRegressions(t *testing.T) {
+	h, counts := countingHandler(t, WithOrderingGuard(0))
+
+	for _, status := range []Status{
+		StatusQueued,
+		StatusEncoding,
+		StatusFinished,
+		StatusProcessing,         // late, dropped
+		StatusResolutionFinished, // late, dropped
+		StatusCaptionsGenerated,  // not a lifecycle step
+		StatusFinished,           // same step, allowed
+	} {
+		if code := serve(h, newRequest(eventBody("video-abc", status))); code != http.StatusOK {
+			t.Fatalf("%v: status = %d, want 200", status, code)
+		}
+	}
+
+	want := map[Status]int{
+		StatusQueued:            1,
+		StatusEncoding:          1,
+		StatusFinished:          2,
+		StatusCaptionsGenerated: 1,
+	}
+	if fmt.Sprint(counts) != fmt.Sprint(want) {
+		t.Errorf("dispatched %v, want %v", counts, want)
+	}
+}
+
+func TestHandler_OrderingGuardIsPerVideo(t *testing.T) {
+	h, counts := countingHandler(t, WithOrderingGuard(0))
+
+	serve(h, newRequest(eventBody("video-abc", StatusFinished)))
+	serve(h, newRequest(eventBody("video-def", StatusQueued)))
+
+	if counts[StatusQueued] != 1 {
+		t.Errorf("Queued for another video dispatched %d times, want 1", counts[StatusQueued])
+	}
+}
+
+func TestHandler_OrderingGuardIgnoresFailedDispatch(t *testing.T) {
+	h := mustNewHandler(t, WithOrderingGuard(0))
+
+	var got []Status
+	h.OnEvent(func(_ context.Context, e Event) error {
+		if e.Status == StatusFinished && len(got) == 0 {
+			got = append(got, -1)
+			return errors.New("boom")
+		}
+		got = append(got, e.Status)
+		return nil
+	})
+
+	serve(h, newRequest(eventBody("video-abc", StatusFinished)))
+	serve(h, newRequest(eventBody("video-abc", StatusEncoding)))
+
+	if len(got) != 2 || got[1] != StatusEncoding {
+		t.Errorf("got %v, want Encoding dispatched after the failed Finished", got)
+	}
+}
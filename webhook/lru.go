@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lru is a size-bounded map evicting its least recently used entries. It is
+// safe for concurrent use.
+type lru[K comparable, V any] struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRU[K comparable, V any](size int) *lru[K, V] {
+	return &lru[K, V]{
+		size:  max(size, 1),
+		order: list.New(),
+		items: make(map[K]*list.Element),
+	}
+}
+
+// update calls fn with the current value of key, if any, and stores the
+// value it returns when store is true. The whole call runs under the lock.
+func (l *lru[K, V]) update(key K, fn func(current V, ok bool) (next V, store bool)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var current V
+	elem, ok := l.items[key]
+	if ok {
+		current = elem.Value.(*lruEntry[K, V]).value
+		l.order.MoveToFront(elem)
+	}
+
+	next, store := fn(current, ok)
+	if !store {
+		return
+	}
+
+	if ok {
+		elem.Value.(*lruEntry[K, V]).value = next
+		return
+	}
+
+	l.items[key] = l.order.PushFront(&lruEntry[K, V]{key: key, value: next})
+	if l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// remove deletes key.
+func (l *lru[K, V]) remove(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.items[key]; ok {
+		l.order.Remove(elem)
+		delete(l.items, key)
+	}
+}
+
+// len returns the number of entries.
+func (l *lru[K, V]) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
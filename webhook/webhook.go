@@ -0,0 +1,444 @@
+// Package webhook receives the video status webhooks Bunny Stream posts to
+// your server.
+//
+// Bunny sends a small JSON payload for every status change of a video:
+//
+//	{"VideoLibraryId": 133, "VideoGuid": "657bb740-a71b-4529-a012-528021c31a92", "Status": 3}
+//
+// Use ParseWebhook to decode a single request, or a Handler to validate and
+// dispatch events to callbacks registered per status.
+//
+// Note that webhook status codes are not the same as the video status codes
+// returned by the API (bunnystream.VideoStatus); see Status.
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+
+	bunnystream "github.com/ArabindaSigdel/bunnystream-go"
+)
+
+// maxPayloadSize bounds the size of a webhook body read by ParseWebhook.
+const maxPayloadSize = 64 << 10
+
+// SecretHeader is the header checked by WithSharedSecret, as an alternative
+// to the "secret" query parameter.
+const SecretHeader = "X-Webhook-Secret"
+
+// Predefined errors.
+var (
+	ErrMethodNotAllowed = errors.New("webhook: method not allowed")
+	ErrInvalidPayload   = errors.New("webhook: invalid payload")
+	ErrLibraryMismatch  = errors.New("webhook: event is for another library")
+	ErrInvalidSecret    = errors.New("webhook: invalid shared secret")
+	ErrIPNotAllowed     = errors.New("webhook: source ip not allowed")
+)
+
+// Status is the status code of a webhook event.
+type Status int
+
+// Webhook statuses sent by Bunny Stream.
+const (
+	StatusQueued                      Status = 0
+	StatusProcessing                  Status = 1
+	StatusEncoding                    Status = 2
+	StatusFinished                    Status = 3
+	StatusResolutionFinished          Status = 4
+	StatusFailed                      Status = 5
+	StatusPresignedUploadStarted      Status = 6
+	StatusPresignedUploadFinished     Status = 7
+	StatusPresignedUploadFailed       Status = 8
+	StatusCaptionsGenerated           Status = 9
+	StatusTitleOrDescriptionGenerated Status = 10
+)
+
+// statusNames maps each known status to its name.
+var statusNames = map[Status]string{
+	StatusQueued:                      "Queued",
+	StatusProcessing:                  "Processing",
+	StatusEncoding:                    "Encoding",
+	StatusFinished:                    "Finished",
+	StatusResolutionFinished:          "ResolutionFinished",
+	StatusFailed:                      "Failed",
+	StatusPresignedUploadStarted:      "PresignedUploadStarted",
+	StatusPresignedUploadFinished:     "PresignedUploadFinished",
+	StatusPresignedUploadFailed:       "PresignedUploadFailed",
+	StatusCaptionsGenerated:           "CaptionsGenerated",
+	StatusTitleOrDescriptionGenerated: "TitleOrDescriptionGenerated",
+}
+
+// String returns the name of the status, e.g. "Finished".
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return "Status(" + strconv.Itoa(int(s)) + ")"
+}
+
+// IsFailed reports whether the event reports a failed encoding or upload.
+func (s Status) IsFailed() bool {
+	return s == StatusFailed || s == StatusPresignedUploadFailed
+}
+
+// Event is a video status change posted by Bunny Stream.
+type Event struct {
+	// VideoLibraryID is the ID of the library the video belongs to.
+	VideoLibraryID int64 `json:"VideoLibraryId"`
+
+	// VideoGUID is the ID of the video.
+	VideoGUID string `json:"VideoGuid"`
+
+	// Status is the new status of the video.
+	Status Status `json:"Status"`
+}
+
+// ParseWebhook decodes the webhook event in r. It only accepts POST requests
+// with a JSON body naming a video.
+func ParseWebhook(r *http.Request) (*Event, error) {
+	if r.Method != http.MethodPost {
+		return nil, ErrMethodNotAllowed
+	}
+
+	if r.Body == nil {
+		return nil, fmt.Errorf("%w: empty body", ErrInvalidPayload)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	if len(body) > maxPayloadSize {
+		return nil, fmt.Errorf("%w: body too large", ErrInvalidPayload)
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	if strings.TrimSpace(event.VideoGUID) == "" {
+		return nil, fmt.Errorf("%w: missing VideoGuid", ErrInvalidPayload)
+	}
+
+	return &event, nil
+}
+
+// EventFunc handles a webhook event. Returning an error answers the webhook
+// with 500 Internal Server Error.
+type EventFunc func(ctx context.Context, event Event) error
+
+type options struct {
+	secret      string
+	allowedIPs  []string
+	proxyHeader string
+	proxyHops   int
+	store       Store
+	guardSize   int
+}
+
+// Option configures a Handler.
+type Option func(*options)
+
+// WithSharedSecret rejects requests that do not carry secret, either in the
+// "secret" query parameter of the webhook URL or in the SecretHeader header.
+// Bunny does not sign webhooks, so configure the webhook URL in the dashboard
+// as e.g. https://example.com/hooks/bunny?secret=....
+func WithSharedSecret(secret string) Option {
+	return func(o *options) {
+		o.secret = secret
+	}
+}
+
+// WithAllowedIPs rejects requests whose source address is not in one of the
+// given IP addresses or CIDR prefixes.
+func WithAllowedIPs(ips ...string) Option {
+	return func(o *options) {
+		o.allowedIPs = append(o.allowedIPs, ips...)
+	}
+}
+
+// WithTrustedProxyHeader reads the source address checked by WithAllowedIPs
+// from header (e.g. "X-Forwarded-For") instead of the connection address.
+// Only use it behind proxies that append to the header.
+//
+// hops is the number of trusted proxies in front of the handler: the
+// address is the hops-th entry counted from the right, i.e. the one added by
+// the outermost trusted proxy. Entries further left are set by the client
+// and never trusted. A hops of less than 1 is treated as 1, the rightmost
+// entry.
+func WithTrustedProxyHeader(header string, hops int) Option {
+	return func(o *options) {
+		o.proxyHeader = header
+		o.proxyHops = max(hops, 1)
+	}
+}
+
+// Handler is an http.Handler receiving Bunny Stream webhooks. It validates
+// every request, then calls the callbacks registered for the event status
+// followed by those registered with OnEvent. With WithDedupe and
+// WithOrderingGuard, redelivered and out-of-order events are acknowledged
+// with 200 OK without running the callbacks.
+//
+// Register callbacks before serving requests.
+type Handler struct {
+	libraryID   int64
+	secret      string
+	allowed     []netip.Prefix
+	proxyHeader string
+	proxyHops   int
+	store       Store
+	guard       *statusGuard
+
+	mu        sync.RWMutex
+	callbacks map[Status][]EventFunc
+	any       []EventFunc
+}
+
+// NewHandler returns a Handler accepting events for cfg.LibraryID only.
+func NewHandler(cfg *bunnystream.Config, opts ...Option) (*Handler, error) {
+	if cfg == nil {
+		return nil, bunnystream.ErrInvalidConfig
+	}
+
+	libraryID, err := strconv.ParseInt(strings.TrimSpace(cfg.LibraryID), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", bunnystream.ErrInvalidConfig, bunnystream.ErrLibraryIDRequired)
+	}
+
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	h := &Handler{
+		libraryID:   libraryID,
+		secret:      o.secret,
+		proxyHeader: o.proxyHeader,
+		proxyHops:   o.proxyHops,
+		store:       o.store,
+		callbacks:   make(map[Status][]EventFunc),
+	}
+
+	if o.guardSize > 0 {
+		h.guard = newStatusGuard(o.guardSize)
+	}
+
+	for _, ip := range o.allowedIPs {
+		prefix, err := parsePrefix(ip)
+		if err != nil {
+			return nil, fmt.Errorf("%w: allowed ip %q: %w", bunnystream.ErrInvalidConfig, ip, err)
+		}
+		h.allowed = append(h.allowed, prefix)
+	}
+
+	return h, nil
+}
+
+// On registers fn for events with the given status.
+func (h *Handler) On(status Status, fn EventFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks[status] = append(h.callbacks[status], fn)
+}
+
+// OnEvent registers fn for every event, after the status callbacks.
+func (h *Handler) OnEvent(fn EventFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.any = append(h.any, fn)
+}
+
+// OnFinished registers fn for videos that finished encoding.
+func (h *Handler) OnFinished(fn EventFunc) {
+	h.On(StatusFinished, fn)
+}
+
+// OnFailed registers fn for videos that failed to encode or upload
+// (StatusFailed and StatusPresignedUploadFailed).
+func (h *Handler) OnFailed(fn EventFunc) {
+	h.On(StatusFailed, fn)
+	h.On(StatusPresignedUploadFailed, fn)
+}
+
+// OnCaptionsGenerated registers fn for videos whose captions were generated.
+func (h *Handler) OnCaptionsGenerated(fn EventFunc) {
+	h.On(StatusCaptionsGenerated, fn)
+}
+
+// OnTitleOrDescriptionGenerated registers fn for videos whose title or
+// description were generated.
+func (h *Handler) OnTitleOrDescriptionGenerated(fn EventFunc) {
+	h.On(StatusTitleOrDescriptionGenerated, fn)
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	event, err := h.Parse(r)
+	if err != nil {
+		http.Error(w, err.Error(), statusCode(err))
+		return
+	}
+
+	if err := h.process(r.Context(), *event); err != nil {
+		http.Error(w, "webhook: handler failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// process dispatches event unless it is a duplicate or regresses its video.
+// A dispatch error removes the event from the store so that Bunny's
+// redelivery runs the callbacks again.
+func (h *Handler) process(ctx context.Context, event Event) error {
+	if h.guard != nil && !h.guard.allows(event) {
+		return nil
+	}
+
+	if h.store != nil {
+		added, err := h.store.Add(ctx, eventKey(event))
+		if err != nil {
+			return err
+		}
+		if !added {
+			return nil
+		}
+	}
+
+	if err := h.Dispatch(ctx, event); err != nil {
+		if h.store != nil {
+			err = errors.Join(err, h.store.Remove(context.WithoutCancel(ctx), eventKey(event)))
+		}
+		return err
+	}
+
+	if h.guard != nil {
+		h.guard.advance(event)
+	}
+	return nil
+}
+
+// Parse authenticates r and decodes its event, checking that it belongs to
+// the configured library.
+func (h *Handler) Parse(r *http.Request) (*Event, error) {
+	if err := h.authenticate(r); err != nil {
+		return nil, err
+	}
+
+	event, err := ParseWebhook(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.VideoLibraryID != h.libraryID {
+		return nil, ErrLibraryMismatch
+	}
+
+	return event, nil
+}
+
+// Dispatch calls the callbacks registered for event, stopping at the first
+// error.
+func (h *Handler) Dispatch(ctx context.Context, event Event) error {
+	h.mu.RLock()
+	callbacks := append(append([]EventFunc(nil), h.callbacks[event.Status]...), h.any...)
+	h.mu.RUnlock()
+
+	for _, fn := range callbacks {
+		if err := fn(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authenticate checks the shared secret and source address of r.
+func (h *Handler) authenticate(r *http.Request) error {
+	if h.secret != "" {
+		got := r.URL.Query().Get("secret")
+		if got == "" {
+			got = r.Header.Get(SecretHeader)
+		}
+		if subtle.ConstantTimeCompare([]byte(got), []byte(h.secret)) != 1 {
+			return ErrInvalidSecret
+		}
+	}
+
+	if len(h.allowed) > 0 {
+		addr, ok := h.sourceAddr(r)
+		if !ok {
+			return ErrIPNotAllowed
+		}
+		for _, prefix := range h.allowed {
+			if prefix.Contains(addr) {
+				return nil
+			}
+		}
+		return ErrIPNotAllowed
+	}
+
+	return nil
+}
+
+// sourceAddr returns the address the request originates from.
+func (h *Handler) sourceAddr(r *http.Request) (netip.Addr, bool) {
+	raw := r.RemoteAddr
+	if h.proxyHeader != "" {
+		// Proxies append to the header, possibly across several lines.
+		entries := strings.Split(strings.Join(r.Header.Values(h.proxyHeader), ","), ",")
+		if len(entries) < h.proxyHops {
+			return netip.Addr{}, false
+		}
+		raw = entries[len(entries)-h.proxyHops]
+	}
+
+	raw = strings.TrimSpace(raw)
+	if host, _, err := net.SplitHostPort(raw); err == nil {
+		raw = host
+	}
+
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// parsePrefix parses an IP address or CIDR prefix.
+func parsePrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		return prefix.Masked(), err
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// statusCode maps a Parse error to an HTTP status code.
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrMethodNotAllowed):
+		return http.StatusMethodNotAllowed
+	case errors.Is(err, ErrInvalidSecret), errors.Is(err, ErrIPNotAllowed), errors.Is(err, ErrLibraryMismatch):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,279 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bunnystream "github.com/ArabindaSigdel/bunnystream-go"
+)
+
+const finishedPayload = `{"VideoLibraryId":123,"VideoGuid":"video-abc","Status":3}`
+
+// newRequest builds a webhook POST carrying body.
+func newRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/hooks/bunny", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+// mustNewHandler creates a handler for library 123.
+func mustNewHandler(t *testing.T, opts ...Option) *Handler {
+	t.Helper()
+	h, err := NewHandler(&bunnystream.Config{APIKey: "test-key", LibraryID: "123"}, opts...)
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+	return h
+}
+
+// serve runs r through h and returns the response status code.
+func serve(h http.Handler, r *http.Request) int {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec.Code
+}
+
+// -----------------------------------------------------------------------------
+// ParseWebhook
+// -----------------------------------------------------------------------------
+
+func TestParseWebhook_DecodesEvent(t *testing.T) {
+	event, err := ParseWebhook(newRequest(finishedPayload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.VideoLibraryID != 123 {
+		t.Errorf("VideoLibraryID = %d, want 123", event.VideoLibraryID)
+	}
+	if event.VideoGUID != "video-abc" {
+		t.Errorf("VideoGUID = %q, want %q", event.VideoGUID, "video-abc")
+	}
+	if event.Status != StatusFinished {
+		t.Errorf("Status = %v, want %v", event.Status, StatusFinished)
+	}
+}
+
+func TestParseWebhook_RejectsGet(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/hooks/bunny", nil)
+	if _, err := ParseWebhook(r); !errors.Is(err, ErrMethodNotAllowed) {
+		t.Errorf("expected ErrMethodNotAllowed, got %v", err)
+	}
+}
+
+func TestParseWebhook_InvalidPayload(t *testing.T) {
+	cases := map[string]string{
+		"malformed":    `{"VideoGuid":`,
+		"missing guid": `{"VideoLibraryId":123,"Status":3}`,
+		"too large":    `{"VideoGuid":"` + strings.Repeat("a", maxPayloadSize) + `"}`,
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseWebhook(newRequest(body)); !errors.Is(err, ErrInvalidPayload) {
+				t.Errorf("expected ErrInvalidPayload, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStatus_String(t *testing.T) {
+	if got := StatusCaptionsGenerated.String(); got != "CaptionsGenerated" {
+		t.Errorf("String() = %q, want %q", got, "CaptionsGenerated")
+	}
+	if got := Status(42).String(); got != "Status(42)" {
+		t.Errorf("String() = %q, want %q", got, "Status(42)")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// NewHandler
+// -----------------------------------------------------------------------------
+
+func TestNewHandler_InvalidConfig(t *testing.T) {
+	if _, err := NewHandler(nil); !errors.Is(err, bunnystream.ErrInvalidConfig) {
+		t.Errorf("nil config: expected ErrInvalidConfig, got %v", err)
+	}
+	if _, err := NewHandler(&bunnystream.Config{LibraryID: "abc"}); !errors.Is(err, bunnystream.ErrLibraryIDRequired) {
+		t.Errorf("non-numeric library: expected ErrLibraryIDRequired, got %v", err)
+	}
+	_, err := NewHandler(&bunnystream.Config{LibraryID: "123"}, WithAllowedIPs("not-an-ip"))
+	if !errors.Is(err, bunnystream.ErrInvalidConfig) {
+		t.Errorf("bad ip: expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Handler dispatch
+// -----------------------------------------------------------------------------
+
+func TestHandler_DispatchesByStatus(t *testing.T) {
+	h := mustNewHandler(t)
+
+	var got []string
+	h.OnFinished(func(_ context.Context, e Event) error {
+		got = append(got, "finished:"+e.VideoGUID)
+		return nil
+	})
+	h.OnFailed(func(_ context.Context, e Event) error {
+		got = append(got, "failed:"+e.Status.String())
+		return nil
+	})
+	h.OnEvent(func(_ context.Context, e Event) error {
+		got = append(got, "any:"+e.Status.String())
+		return nil
+	})
+
+	for _, body := range []string{
+		finishedPayload,
+		`{"VideoLibraryId":123,"VideoGuid":"video-abc","Status":8}`,
+		`{"VideoLibraryId":123,"VideoGuid":"video-abc","Status":1}`,
+	} {
+		if code := serve(h, newRequest(body)); code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", code)
+		}
+	}
+
+	want := []string{
+		"finished:video-abc", "any:Finished",
+		"failed:PresignedUploadFailed", "any:PresignedUploadFailed",
+		"any:Processing",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestHandler_CallbackErrorReturns500(t *testing.T) {
+	h := mustNewHandler(t)
+	h.OnFinished(func(context.Context, Event) error { return errors.New("boom") })
+
+	if code := serve(h, newRequest(finishedPayload)); code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", code)
+	}
+}
+
+func TestHandler_RejectsOtherLibrary(t *testing.T) {
+	h := mustNewHandler(t)
+	called := false
+	h.OnEvent(func(context.Context, Event) error { called = true; return nil })
+
+	body := `{"VideoLibraryId":999,"VideoGuid":"video-abc","Status":3}`
+	if code := serve(h, newRequest(body)); code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", code)
+	}
+	if called {
+		t.Error("callback should not run for another library")
+	}
+}
+
+func TestHandler_StatusCodes(t *testing.T) {
+	h := mustNewHandler(t)
+
+	if code := serve(h, httptest.NewRequest(http.MethodGet, "/", nil)); code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", code)
+	}
+	if code := serve(h, newRequest(`nope`)); code != http.StatusBadRequest {
+		t.Errorf("bad body status = %d, want 400", code)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Handler authentication
+// -----------------------------------------------------------------------------
+
+func TestHandler_SharedSecret(t *testing.T) {
+	h := mustNewHandler(t, WithSharedSecret("s3cret"))
+
+	if code := serve(h, newRequest(finishedPayload)); code != http.StatusForbidden {
+		t.Errorf("missing secret status = %d, want 403", code)
+	}
+
+	r := newRequest(finishedPayload)
+	r.URL.RawQuery = "secret=wrong"
+	if code := serve(h, r); code != http.StatusForbidden {
+		t.Errorf("wrong secret status = %d, want 403", code)
+	}
+
+	r = newRequest(finishedPayload)
+	r.URL.RawQuery = "secret=s3cret"
+	if code := serve(h, r); code != http.StatusOK {
+		t.Errorf("query secret status = %d, want 200", code)
+	}
+
+	r = newRequest(finishedPayload)
+	r.Header.Set(SecretHeader, "s3cret")
+	if code := serve(h, r); code != http.StatusOK {
+		t.Errorf("header secret status = %d, want 200", code)
+	}
+}
+
+func TestHandler_AllowedIPs(t *testing.T) {
+	h := mustNewHandler(t, WithAllowedIPs("10.0.0.0/8", "192.0.2.7"))
+
+	cases := map[string]int{
+		"10.1.2.3:4000":  http.StatusOK,
+		"192.0.2.7:4000": http.StatusOK,
+		"192.0.2.8:4000": http.StatusForbidden,
+		"garbage":        http.StatusForbidden,
+	}
+	for remote, want := range cases {
+		r := newRequest(finishedPayload)
+		r.RemoteAddr = remote
+		if code := serve(h, r); code != want {
+			t.Errorf("RemoteAddr %q: status = %d, want %d", remote, code, want)
+		}
+	}
+}
+
+func TestHandler_TrustedProxyHeader(t *testing.T) {
+	cases := []struct {
+		name string
+		xff  []string
+		want int
+	}{
+		{"appended by proxy", []string{"10.9.9.9"}, http.StatusOK},
+		{"client entries before it", []string{"203.0.113.1, 10.9.9.9"}, http.StatusOK},
+		{"spoofed first entry", []string{"10.1.2.3, 203.0.113.9"}, http.StatusForbidden},
+		{"spoofed across lines", []string{"10.1.2.3", "203.0.113.9"}, http.StatusForbidden},
+		{"missing", nil, http.StatusForbidden},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			h := mustNewHandler(t, WithAllowedIPs("10.0.0.0/8"), WithTrustedProxyHeader("X-Forwarded-For", 1))
+			h.OnEvent(func(context.Context, Event) error { called = true; return nil })
+
+			r := newRequest(finishedPayload)
+			r.RemoteAddr = "10.0.0.1:4000"
+			for _, v := range tt.xff {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if code := serve(h, r); code != tt.want {
+				t.Errorf("status = %d, want %d", code, tt.want)
+			}
+			if called != (tt.want == http.StatusOK) {
+				t.Errorf("callback called = %v", called)
+			}
+		})
+	}
+}
+
+func TestHandler_TrustedProxyHops(t *testing.T) {
+	// Two trusted proxies: the client address is the second entry from the right.
+	h := mustNewHandler(t, WithAllowedIPs("10.0.0.0/8"), WithTrustedProxyHeader("X-Forwarded-For", 2))
+
+	r := newRequest(finishedPayload)
+	r.Header.Set("X-Forwarded-For", "192.0.2.1, 10.9.9.9, 172.16.0.5")
+	if code := serve(h, r); code != http.StatusOK {
+		t.Errorf("status = %d, want 200", code)
+	}
+
+	r = newRequest(finishedPayload)
+	r.Header.Set("X-Forwarded-For", "10.9.9.9, 192.0.2.1, 172.16.0.5")
+	if code := serve(h, r); code != http.StatusForbidden {
+		t.Errorf("spoofed status = %d, want 403", code)
+	}
+}